@@ -1,47 +1,83 @@
 package atf
 
 import (
+	"net/url"
 	"strconv"
 	"strings"
-
-	"github.com/gin-gonic/gin"
 )
 
-func getPaidResource(c *gin.Context) (rv Resource) {
-	if rv := c.PostForm("paid-resource"); rv == "" {
+func getPaidResource(form url.Values) (rv Resource) {
+	if rv := form.Get("paid-resource"); rv == "" {
 		return noResource
 	} else {
 		return toResource(rv)
 	}
 }
 
-func getPlacedArmies(c *gin.Context) int {
-	if a, err := strconv.Atoi(c.PostForm("placed-armies")); err == nil {
+func getPlacedArmies(form url.Values) int {
+	if a, err := strconv.Atoi(form.Get("placed-armies")); err == nil {
 		return a
 	}
 	return 0
 }
 
-func getPlaceWorkers(c *gin.Context) int {
-	if w, err := strconv.Atoi(c.PostForm("place-workers")); err == nil {
+func getPlaceWorkers(form url.Values) int {
+	if w, err := strconv.Atoi(form.Get("place-workers")); err == nil {
 		return w
 	}
 	return 0
 }
 
-func getAreaID(c *gin.Context) AreaID {
-	return toAreaID(c.PostForm("area"))
+func getAreaID(form url.Values) AreaID {
+	return toAreaID(form.Get("area"))
 }
 
-func getTrades(c *gin.Context) (gave, received Resources) {
+func getTrades(form url.Values) (gave, received Resources) {
 	gave = make(Resources, 8)
 	received = make(Resources, 8)
 	for i, s := range resourceStrings {
 		key := strings.ToLower(s) + "-traded-resource"
-		if res := c.PostForm(key); res != "" && res != "none" {
-			gave[toResource(res)] += 1
-			received[i] += 1
+		// a resource is received more than once by posting its key once per resource given
+		for _, res := range form[key] {
+			if res != "" && res != "none" {
+				gave[toResource(res)] += 1
+				received[i] += 1
+			}
 		}
 	}
 	return
 }
+
+func parseArea(args *actionArgs) error {
+	args.Area = getAreaID(args.Form)
+	return nil
+}
+
+func parseResources(args *actionArgs) (err error) {
+	args.Resources, err = getResourcesFrom(args)
+	return
+}
+
+func parsePlacedArmies(args *actionArgs) error {
+	args.Count = getPlacedArmies(args.Form)
+	return nil
+}
+
+func parsePlaceWorkers(args *actionArgs) error {
+	args.Resource, args.Count = getPaidResource(args.Form), getPlaceWorkers(args.Form)
+	return nil
+}
+
+func parseTrades(args *actionArgs) error {
+	args.Resources, args.Received = getTrades(args.Form)
+	return nil
+}
+
+func parseActionCost(args *actionArgs) error {
+	r, err := strconv.Atoi(args.Form.Get("Resource"))
+	if err != nil {
+		return err
+	}
+	args.Resource = Resource(r)
+	return nil
+}
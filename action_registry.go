@@ -0,0 +1,459 @@
+package atf
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/SlothNinja/game"
+	"github.com/SlothNinja/sn"
+	"github.com/SlothNinja/user"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type actionRole int
+
+const (
+	playerRole actionRole = iota
+	adminRole
+)
+
+func (r actionRole) String() string {
+	if r == adminRole {
+		return "admin"
+	}
+	return "player"
+}
+
+// actionParam declares a form value read by an action.
+type actionParam struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// actionArgs holds the arguments of a submitted action.
+type actionArgs struct {
+	// Form holds the submitted values of the declared params.
+	Form      url.Values
+	Area      AreaID
+	Resource  Resource
+	Count     int
+	Resources Resources
+	// Received holds the resources received in exchange for Resources.
+	Received Resources
+}
+
+// bind binds the declared form values to obj, as gin binds the form of a request.
+func (args *actionArgs) bind(obj interface{}) error {
+	return binding.Form.Bind(&http.Request{Form: args.Form}, obj)
+}
+
+// actionDef declares an action that may be submitted to Game.Update.
+type actionDef struct {
+	Name string
+	Role actionRole
+	// Phases lists the phases in which the action is permitted.  Any phase, if empty.
+	Phases []game.Phase
+	// Params declares the form values read by the action, in addition to "action".
+	Params []actionParam
+	// Parse, if provided, converts the declared form values into the arguments of the action.
+	Parse func(*actionArgs) error
+	// Validate, if provided, checks that the user may perform the action with the parsed arguments.
+	Validate func(*Game, *user.User, *actionArgs) error
+	// Execute performs the validated action.
+	// It returns the template rendering the result, or "" to render Template.
+	Execute func(*Game, *gin.Context, *user.User, *actionArgs) (string, game.ActionType, error)
+	// Template renders the result of the action.
+	// Actions selecting a template based on the game state, or redirecting to the game, have none.
+	Template string
+}
+
+// actionStats counts the submissions of an action.
+type actionStats struct {
+	Performed int `json:"performed"`
+	Rejected  int `json:"rejected"`
+	Failed    int `json:"failed"`
+}
+
+// Registries are populated during package initialization and are read-only afterwards.
+var (
+	actionDefs = make(map[string]*actionDef)
+
+	statsMu sync.Mutex
+	stats   = make(map[string]*actionStats)
+)
+
+var resourceParams = []actionParam{
+	{Name: "grain", Description: "Number of grain."},
+	{Name: "wood", Description: "Number of wood."},
+	{Name: "metal", Description: "Number of metal."},
+	{Name: "textile", Description: "Number of textile."},
+	{Name: "tool", Description: "Number of tools."},
+	{Name: "oil", Description: "Number of oil."},
+	{Name: "gold", Description: "Number of gold."},
+	{Name: "lapis", Description: "Number of lapis."},
+}
+
+// tradeParams declare, for each resource received, the resources given.
+var tradeParams = func() []actionParam {
+	ps := make([]actionParam, 0, Lapis+1)
+	for r := Grain; r <= Lapis; r++ {
+		ps = append(ps, actionParam{
+			Name:        r.LString() + "-traded-resource",
+			Description: "Resource given to receive a " + r.LString() + ".  Repeat to receive more than one.",
+		})
+	}
+	return ps
+}()
+
+func init() {
+	actions := []game.Phase{Actions}
+	for _, d := range []*actionDef{
+		{
+			Name:     "select-area",
+			Params:   []actionParam{{Name: "area", Description: "Name of the area selected."}},
+			Parse:    parseArea,
+			Validate: (*Game).validateSelectArea,
+			Execute:  (*Game).selectArea,
+		},
+		{
+			Name:     "build-city",
+			Phases:   actions,
+			Validate: (*Game).validateBuildCity,
+			Execute:  (*Game).buildCity,
+			Template: "atf/cities_update",
+		},
+		{
+			Name:     "abandon-city",
+			Phases:   actions,
+			Validate: (*Game).validateAbandonCity,
+			Execute:  (*Game).abandonCity,
+			Template: "atf/cities_update",
+		},
+		{
+			Name:     "buy-armies",
+			Phases:   actions,
+			Params:   []actionParam{resourceParams[Grain], resourceParams[Metal], resourceParams[Tool]},
+			Parse:    parseResources,
+			Validate: (*Game).validateBuyArmies,
+			Execute:  (*Game).buyArmies,
+			Template: "atf/buy_armies_update",
+		},
+		{
+			Name:     "equip-army",
+			Phases:   actions,
+			Params:   resourceParams,
+			Parse:    parseResources,
+			Validate: (*Game).validateEquipArmy,
+			Execute:  (*Game).equipArmy,
+			Template: "atf/equip_army_update",
+		},
+		{
+			Name:     "place-armies",
+			Phases:   actions,
+			Params:   []actionParam{{Name: "placed-armies", Description: "Number of armies placed, 1 or 2."}},
+			Parse:    parsePlacedArmies,
+			Validate: (*Game).validatePlaceArmies,
+			Execute:  (*Game).placeArmies,
+			Template: "atf/place_armies_update",
+		},
+		{
+			Name:   "place-workers",
+			Phases: actions,
+			Params: []actionParam{
+				{Name: "paid-resource", Description: "Resource spent to place the workers."},
+				{Name: "place-workers", Description: "Number of workers placed."},
+			},
+			Parse:    parsePlaceWorkers,
+			Validate: (*Game).validatePlaceWorkers,
+			Execute:  (*Game).placeWorkers,
+			Template: "atf/place_workers_update",
+		},
+		{
+			Name:     "trade-resource",
+			Phases:   actions,
+			Params:   tradeParams,
+			Parse:    parseTrades,
+			Validate: (*Game).validateTrade,
+			Execute:  (*Game).tradeResource,
+			Template: "atf/trade_update",
+		},
+		{
+			Name:     "use-scribe",
+			Phases:   actions,
+			Validate: (*Game).validateUseScribe,
+			Execute:  (*Game).useScribe,
+			Template: "atf/use_scribe_update",
+		},
+		{
+			Name:     "from-stock",
+			Phases:   actions,
+			Validate: (*Game).validateFromStock,
+			Execute:  (*Game).fromStock,
+			Template: "atf/select_worker_from_stock_update",
+		},
+		{
+			Name:     "make-tool",
+			Phases:   actions,
+			Validate: (*Game).validateMakeTool,
+			Execute:  (*Game).makeTool,
+			Template: "atf/make_tool_update",
+		},
+		{
+			Name:     "start-empire",
+			Phases:   actions,
+			Validate: (*Game).validateStartEmpire,
+			Execute:  (*Game).startEmpire,
+			Template: "atf/area_dialog",
+		},
+		{
+			Name:     "cancel-start-empire",
+			Phases:   actions,
+			Validate: (*Game).validateCancelStartEmpire,
+			Execute:  (*Game).cancelStartEmpire,
+		},
+		{
+			Name:     "confirm-start-empire",
+			Phases:   actions,
+			Validate: (*Game).validateConfirmStartEmpire,
+			Execute:  (*Game).confirmStartEmpire,
+		},
+		{
+			Name:     "invade-area",
+			Phases:   actions,
+			Validate: (*Game).validateInvadeArea,
+			Execute:  (*Game).invadeArea,
+			Template: "atf/invade_area_update",
+		},
+		{
+			Name:     "invade-area-warning",
+			Phases:   actions,
+			Validate: (*Game).validateInvadeAreaWarning,
+			Execute:  (*Game).invadeAreaWarning,
+			Template: "atf/invade_area_warning_dialog",
+		},
+		{
+			Name:     "cancel-invasion",
+			Phases:   actions,
+			Validate: (*Game).validateCancelInvasion,
+			Execute:  (*Game).cancelInvasion,
+		},
+		{
+			Name:     "confirm-invasion",
+			Phases:   actions,
+			Validate: (*Game).validateConfirmInvasion,
+			Execute:  (*Game).confirmInvasion,
+		},
+		{
+			Name:     "reinforce-army",
+			Phases:   actions,
+			Validate: (*Game).validateReinforceArmy,
+			Execute:  (*Game).reinforceArmy,
+			Template: "atf/reinforce_army_update",
+		},
+		{
+			Name:     "destroy-city",
+			Phases:   actions,
+			Validate: (*Game).validateDestroyCity,
+			Execute:  (*Game).destroyCity,
+			Template: "atf/destroy_city_update",
+		},
+		{
+			Name:     "pass",
+			Phases:   actions,
+			Params:   resourceParams,
+			Parse:    parseResources,
+			Validate: (*Game).validatePass,
+			Execute:  (*Game).pass,
+			Template: "atf/pass_update",
+		},
+		{
+			Name:     "pay-action-cost",
+			Phases:   actions,
+			Params:   []actionParam{{Name: "Resource", Description: "Number of the resource, army or worker paid."}},
+			Parse:    parseActionCost,
+			Validate: (*Game).validatePayActionCost,
+			Execute:  (*Game).payActionCost,
+			Template: "atf/paid_action_cost_update",
+		},
+		{
+			Name:     "expand-city",
+			Phases:   []game.Phase{ExpandCity},
+			Params:   resourceParams,
+			Parse:    parseResources,
+			Validate: (*Game).validateExpandCity,
+			Execute:  (*Game).expandCity,
+			Template: "atf/expand_city_update",
+		},
+		{Name: "admin-header", Role: adminRole, Params: formParams(adminHeaderForm{}), Execute: (*Game).adminHeader},
+		{Name: "admin-sumer-area", Role: adminRole, Params: formParams(adminSumerAreaForm{}), Execute: (*Game).adminSumerArea},
+		{
+			Name:    "admin-non-sumer-area",
+			Role:    adminRole,
+			Params:  formParams(adminNonSumerAreaForm{}),
+			Execute: (*Game).adminNonSumerArea,
+		},
+		{Name: "admin-worker-box", Role: adminRole, Params: formParams(adminWorkerBoxForm{}), Execute: (*Game).adminWorkerBox},
+		{Name: "admin-player", Role: adminRole, Params: formParams(adminPlayerForm{}), Execute: (*Game).adminPlayer},
+		{
+			Name:    "admin-supply-table",
+			Role:    adminRole,
+			Params:  formParams(adminSupplyTableForm{}),
+			Execute: (*Game).adminSupplyTable,
+		},
+	} {
+		registerAction(d)
+	}
+}
+
+// formParams declares the form values bound to the fields of the provided form struct.
+func formParams(form interface{}) []actionParam {
+	t := reflect.TypeOf(form)
+	ps := make([]actionParam, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := t.Field(i).Tag.Get("form"); name != "" {
+			ps = append(ps, actionParam{Name: name})
+		}
+	}
+	return ps
+}
+
+// registerAction adds d to the registry, replacing any action of the same name.
+// Variants may register additional actions during package initialization.
+func registerAction(d *actionDef) {
+	actionDefs[d.Name] = d
+}
+
+func actionDefFor(name string) (*actionDef, bool) {
+	d, ok := actionDefs[name]
+	return d, ok
+}
+
+// authorize validates that cu may perform the action in the current phase of g.
+func (d *actionDef) authorize(g *Game, cu *user.User) error {
+	switch d.Role {
+	case adminRole:
+		if err := g.validateAdminAction(cu); err != nil {
+			return err
+		}
+	default:
+		if err := g.validatePlayerAction(cu); err != nil {
+			return err
+		}
+	}
+
+	if len(d.Phases) == 0 {
+		return nil
+	}
+	for _, ph := range d.Phases {
+		if g.Phase == ph {
+			return nil
+		}
+	}
+	return sn.NewVError("You can not perform %s during the %s phase.", d.Name, g.PhaseName())
+}
+
+// parse reads the declared params of the action from the request.
+func (d *actionDef) parse(c *gin.Context) (*actionArgs, error) {
+	args := &actionArgs{Form: make(url.Values, len(d.Params))}
+	for _, p := range d.Params {
+		if vs := c.PostFormArray(p.Name); len(vs) > 0 {
+			args.Form[p.Name] = vs
+		}
+	}
+
+	if d.Parse == nil {
+		return args, nil
+	}
+	return args, d.Parse(args)
+}
+
+// try authorizes, parses, validates and executes the action without counting the outcome.
+func (d *actionDef) try(g *Game, c *gin.Context, cu *user.User) (string, game.ActionType, error) {
+	err := d.authorize(g, cu)
+	if err != nil {
+		return "atf/flash_notice", game.None, err
+	}
+
+	args, err := d.parse(c)
+	if err != nil {
+		return "atf/flash_notice", game.None, err
+	}
+
+	if d.Validate != nil {
+		err = d.Validate(g, cu, args)
+		if err != nil {
+			return "atf/flash_notice", game.None, err
+		}
+	}
+
+	tmpl, act, err := d.Execute(g, c, cu, args)
+	if err != nil {
+		return "atf/flash_notice", game.None, err
+	}
+
+	if tmpl == "" {
+		tmpl = d.Template
+	}
+	return tmpl, act, nil
+}
+
+// perform authorizes and performs the action, counting the outcome.
+func (d *actionDef) perform(g *Game, c *gin.Context, cu *user.User) (string, game.ActionType, error) {
+	tmpl, act, err := d.try(g, c, cu)
+
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	s, ok := stats[d.Name]
+	if !ok {
+		s = new(actionStats)
+		stats[d.Name] = s
+	}
+	switch {
+	case err == nil:
+		s.Performed += 1
+	case sn.IsVError(err):
+		s.Rejected += 1
+	default:
+		s.Failed += 1
+	}
+	return tmpl, act, err
+}
+
+type jActionDef struct {
+	Name     string        `json:"name"`
+	Role     string        `json:"role"`
+	Phases   []string      `json:"phases,omitempty"`
+	Params   []actionParam `json:"params,omitempty"`
+	Template string        `json:"template,omitempty"`
+	Stats    actionStats   `json:"stats"`
+}
+
+// actionDocs describes the registered actions and how often each was submitted to this instance.
+// Previews are not counted.
+func (client *Client) actionDocs(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		statsMu.Lock()
+		defer statsMu.Unlock()
+
+		jds := make([]jActionDef, 0, len(actionDefs))
+		for _, d := range actionDefs {
+			jd := jActionDef{Name: d.Name, Role: d.Role.String(), Params: d.Params, Template: d.Template}
+			for _, ph := range d.Phases {
+				jd.Phases = append(jd.Phases, PhaseNames[ph])
+			}
+			if s, ok := stats[d.Name]; ok {
+				jd.Stats = *s
+			}
+			jds = append(jds, jd)
+		}
+		sort.Slice(jds, func(i, j int) bool { return jds[i].Name < jds[j].Name })
+		c.JSON(http.StatusOK, gin.H{"actions": jds})
+	}
+}
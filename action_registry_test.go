@@ -0,0 +1,107 @@
+package atf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestActionDefsDeclared(t *testing.T) {
+	for name, d := range actionDefs {
+		if d.Execute == nil {
+			t.Errorf("%s: no Execute", name)
+		}
+		if d.Role == playerRole && d.Validate == nil {
+			t.Errorf("%s: no Validate", name)
+		}
+		if len(d.Params) == 0 && d.Parse != nil {
+			t.Errorf("%s: Parse declared without params", name)
+		}
+		for _, p := range d.Params {
+			if p.Name == "" {
+				t.Errorf("%s: unnamed param", name)
+			}
+		}
+	}
+}
+
+func TestActionParse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		action string
+		form   url.Values
+		want   actionArgs
+	}{
+		{
+			action: "select-area",
+			form:   url.Values{"area": {"Sumer"}},
+			want:   actionArgs{Area: Sumer},
+		},
+		{
+			action: "buy-armies",
+			form:   url.Values{"grain": {"1"}, "metal": {"2"}, "wood": {"3"}},
+			want:   actionArgs{Resources: Resources{1, 0, 2, 0, 0, 0, 0, 0}},
+		},
+		{
+			action: "place-workers",
+			form:   url.Values{"paid-resource": {"textile"}, "place-workers": {"2"}},
+			want:   actionArgs{Resource: Textile, Count: 2},
+		},
+		{
+			action: "trade-resource",
+			form:   url.Values{"gold-traded-resource": {"grain", "wood", "none"}},
+			want: actionArgs{
+				Resources: Resources{1, 1, 0, 0, 0, 0, 0, 0},
+				Received:  Resources{0, 0, 0, 0, 0, 0, 2, 0},
+			},
+		},
+		{
+			action: "pay-action-cost",
+			form:   url.Values{"Resource": {"8"}},
+			want:   actionArgs{Resource: Army},
+		},
+	}
+
+	for _, test := range tests {
+		d, ok := actionDefFor(test.action)
+		if !ok {
+			t.Fatalf("%s: not registered", test.action)
+		}
+
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.form.Encode()))
+		c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		args, err := d.parse(c)
+		if err != nil {
+			t.Errorf("%s: parse = %v", test.action, err)
+			continue
+		}
+		args.Form = nil
+		if !reflect.DeepEqual(*args, test.want) {
+			t.Errorf("%s: args = %+v, want %+v", test.action, *args, test.want)
+		}
+	}
+}
+
+func TestActionArgsBind(t *testing.T) {
+	args := &actionArgs{Form: url.Values{"armies": {"2"}, "city-built": {"true"}, "city-owner-id": {"1"}}}
+
+	var got adminSumerAreaForm
+	if err := args.bind(&got); err != nil {
+		t.Fatalf("bind = %v", err)
+	}
+	if want := (adminSumerAreaForm{Armies: 2, Built: true, OwnerID: 1}); got != want {
+		t.Errorf("bind = %+v, want %+v", got, want)
+	}
+
+	if got, want := len(formParams(adminSumerAreaForm{})), 5; got != want {
+		t.Errorf("formParams = %d params, want %d", got, want)
+	}
+}
@@ -27,19 +27,3 @@ func (g *Game) validateAdminAction(cu *user.User) error {
 	}
 	return nil
 }
-
-type MultiActionID int
-
-const (
-	noMultiAction MultiActionID = iota
-	startedEmpireMA
-	boughtArmiesMA
-	equippedArmyMA
-	placedArmiesMA
-	usedScribeMA
-	selectedWorkerMA
-	placedWorkerMA
-	tradedResourceMA
-	expandEmpireMA
-	builtCityMA
-)
@@ -88,55 +88,9 @@ func areaIDS() AreaIDS {
 		Egypt, Amorites, Hittites, Mittani, Assyria, Kassites, Guti, Elam, Dilmun, Chaldea, Larsa, Isin, Akkad}
 }
 
-func workerBoxIDS() AreaIDS {
-	return AreaIDS{Irrigation, Weaving, Scribes, NewScribes, UsedScribes, ToolMakers, UsedToolMakers}
-}
-
-func sumerIDS() AreaIDS {
-	return AreaIDS{Sippar, Babylon, Nippur, Shuruppak, Umma, Uruk, Ur, Lagash, Eridu}
-}
-
-func nonSumerIDS() AreaIDS {
-	return AreaIDS{Egypt, Amorites, Hittites, Mittani, Assyria, Kassites, Guti, Elam, Dilmun,
-		Chaldea, Larsa, Isin, Akkad}
-}
-
-func empireIDS() AreaIDS {
-	return AreaIDS{Sippar, Babylon, Nippur, Shuruppak, Umma, Uruk, Ur, Lagash, Eridu,
-		Egypt, Amorites, Hittites, Mittani, Assyria, Kassites, Guti, Elam, Chaldea, Larsa, Isin, Akkad}
-}
-
-func declineIDS() AreaIDS {
-	return AreaIDS{Irrigation, Weaving, Scribes, ToolMakers, Egypt, Amorites, Hittites, Mittani,
-		Assyria, Kassites, Guti, Elam, Dilmun, Chaldea, Larsa, Isin, Akkad}
-}
-
-func scoringIDS() AreaIDS {
-	return AreaIDS{Irrigation, Weaving, Egypt, Amorites, Hittites, Mittani,
-		Assyria, Kassites, Guti, Elam, Dilmun, Chaldea}
-}
-
-func tradeIDS() AreaIDS {
-	return AreaIDS{Egypt, Amorites, Hittites, Mittani, Kassites, Elam, Dilmun, Chaldea}
-}
-
-var scoringMap = map[AreaID]int{
-	Irrigation: 3,
-	Weaving:    4,
-	Egypt:      4,
-	Amorites:   4,
-	Hittites:   3,
-	Mittani:    3,
-	Assyria:    2,
-	Kassites:   4,
-	Guti:       2,
-	Elam:       3,
-	Dilmun:     2,
-	Chaldea:    3,
-}
-
+// Score returns the end-game value of the area.
 func (a *Area) Score() int {
-	return scoringMap[a.ID]
+	return a.g.boardMap().defs[a.ID].Value
 }
 
 func (ids AreaIDS) include(aid AreaID) bool {
@@ -232,28 +186,28 @@ func (a *Area) IsSumer() bool {
 	if a == nil {
 		return false
 	}
-	return sumerIDS().include(a.ID)
+	return a.g.sumerIDS().include(a.ID)
 }
 
 func (a *Area) IsNonSumer() bool {
 	if a == nil {
 		return false
 	}
-	return nonSumerIDS().include(a.ID)
+	return a.g.nonSumerIDS().include(a.ID)
 }
 
 func (a *Area) IsWorkerBox() bool {
 	if a == nil {
 		return false
 	}
-	return workerBoxIDS().include(a.ID)
+	return a.g.workerBoxIDS().include(a.ID)
 }
 
 func (a *Area) IsTradeArea() bool {
 	if a == nil {
 		return false
 	}
-	return tradeIDS().include(a.ID)
+	return a.g.tradeIDS().include(a.ID)
 }
 
 func (a *Area) ArmyOwner() *Player {
@@ -310,17 +264,10 @@ func (c *City) setOwner(p *Player) {
 }
 
 func (g *Game) createAreas() {
-	length := len(workerBoxIDS()) + len(sumerIDS()) + len(nonSumerIDS())
-	g.Areas = make(Areas, length)
+	m := g.boardMap()
+	g.Areas = make(Areas, len(areaIDS()))
 	for _, id := range areaIDS() {
-		// Initial workers
-		switch id {
-		case Irrigation, Weaving:
-			g.Areas[id] = g.newArea(id, 1)
-		default:
-			g.Areas[id] = g.newArea(id, 0)
-		}
-
+		g.Areas[id] = g.newArea(id, m.defs[id].Workers)
 		g.Areas[id].resetTrade()
 	}
 }
@@ -333,28 +280,8 @@ func (g *Game) initAreas() {
 
 func (a *Area) resetTrade() {
 	a.Trade = defaultTradeResources()
-	switch a.ID {
-	case Hittites:
-		a.Trade[Metal] = trade
-	case Mittani:
-		a.Trade[Wood] = trade
-		a.Trade[Metal] = trade
-	case Amorites:
-		a.Trade[Wood] = trade
-		a.Trade[Oil] = trade
-	case Kassites:
-		a.Trade[Lapis] = trade
-	case Egypt:
-		a.Trade[Gold] = trade
-	case Elam:
-		a.Trade[Metal] = trade
-	case Chaldea:
-		a.Trade[Oil] = trade
-	case Dilmun:
-		a.Trade[Wood] = trade
-		a.Trade[Metal] = trade
-		a.Trade[Oil] = trade
-		a.Trade[Gold] = trade
+	for _, r := range a.g.boardMap().trade[a.ID] {
+		a.Trade[r] = trade
 	}
 }
 
@@ -384,33 +311,8 @@ func (a *Area) traded() int {
 	return count
 }
 
-var areaIDSAdjacentTo = map[AreaID]AreaIDS{
-	Sippar:    AreaIDS{Amorites, Assyria, Babylon},
-	Babylon:   AreaIDS{Amorites, Assyria, Sippar, Akkad, Kassites, Shuruppak, Nippur},
-	Nippur:    AreaIDS{Babylon, Kassites, Guti, Umma, Shuruppak},
-	Shuruppak: AreaIDS{Isin, Babylon, Nippur, Umma, Uruk},
-	Umma:      AreaIDS{Nippur, Guti, Elam, Lagash, Ur, Uruk, Shuruppak},
-	Uruk:      AreaIDS{Larsa, Shuruppak, Umma, Ur},
-	Ur:        AreaIDS{Chaldea, Uruk, Umma, Lagash, Eridu},
-	Lagash:    AreaIDS{Umma, Elam, Eridu, Ur},
-	Eridu:     AreaIDS{Ur, Lagash},
-	Egypt:     AreaIDS{Amorites},
-	Amorites:  AreaIDS{Egypt, Hittites, Mittani, Assyria, Sippar, Babylon},
-	Hittites:  AreaIDS{Mittani, Amorites},
-	Mittani:   AreaIDS{Hittites, Amorites, Assyria},
-	Assyria:   AreaIDS{Amorites, Mittani, Kassites, Sippar, Babylon},
-	Kassites:  AreaIDS{Assyria, Babylon, Nippur},
-	Guti:      AreaIDS{Nippur, Umma},
-	Elam:      AreaIDS{Umma, Lagash},
-	Dilmun:    AreaIDS{},
-	Chaldea:   AreaIDS{},
-	Larsa:     AreaIDS{},
-	Isin:      AreaIDS{},
-	Akkad:     AreaIDS{},
-}
-
 func (g *Game) areasAdjacentTo(a *Area) Areas {
-	aids := areaIDSAdjacentTo[a.ID]
+	aids := g.boardMap().adjacent[a.ID]
 	areas := make(Areas, len(aids))
 	for i, aid := range aids {
 		areas[i] = g.Areas[aid]
@@ -418,21 +320,22 @@ func (g *Game) areasAdjacentTo(a *Area) Areas {
 	return areas
 }
 
-func (g *Game) adminSumerArea(c *gin.Context, cu *user.User) (string, game.ActionType, error) {
+type adminSumerAreaForm struct {
+	Armies      int  `form:"armies"`
+	ArmyOwnerID int  `form:"army-owner-id"`
+	Built       bool `form:"city-built"`
+	Expanded    bool `form:"city-expanded"`
+	OwnerID     int  `form:"city-owner-id"`
+}
+
+func (g *Game) adminSumerArea(c *gin.Context, cu *user.User, args *actionArgs) (string, game.ActionType, error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
 	a := g.SelectedArea()
 	// 	na := g.newArea(a.ID, 0)
-	na := struct {
-		Armies      int  `form:"armies"`
-		ArmyOwnerID int  `form:"army-owner-id"`
-		Built       bool `form:"city-built"`
-		Expanded    bool `form:"city-expanded"`
-		OwnerID     int  `form:"city-owner-id"`
-	}{}
-
-	err := c.ShouldBind(&na)
+	var na adminSumerAreaForm
+	err := args.bind(&na)
 	if err != nil {
 		return "", game.None, err
 	}
@@ -462,19 +365,20 @@ func (g *Game) adminSumerArea(c *gin.Context, cu *user.User) (string, game.Actio
 	// return
 }
 
-func (g *Game) adminNonSumerArea(c *gin.Context, cu *user.User) (string, game.ActionType, error) {
+type adminNonSumerAreaForm struct {
+	Workers     Workers   `form:"workers"`
+	Armies      int       `form:"armies"`
+	ArmyOwnerID int       `form:"army-owner-id"`
+	Trade       Resources `form:"trade"`
+}
+
+func (g *Game) adminNonSumerArea(c *gin.Context, cu *user.User, args *actionArgs) (string, game.ActionType, error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
 	a := g.SelectedArea()
-	na := struct {
-		Workers     Workers   `form:"workers"`
-		Armies      int       `form:"armies"`
-		ArmyOwnerID int       `form:"army-owner-id"`
-		Trade       Resources `form:"trade"`
-	}{}
-
-	err := c.ShouldBind(&na)
+	var na adminNonSumerAreaForm
+	err := args.bind(&na)
 	if err != nil {
 		return "", game.None, err
 	}
@@ -509,16 +413,17 @@ func (ss sslice) include(s string) bool {
 	return false
 }
 
-func (g *Game) adminWorkerBox(c *gin.Context, cu *user.User) (string, game.ActionType, error) {
+type adminWorkerBoxForm struct {
+	Workers Workers `form:"workers"`
+}
+
+func (g *Game) adminWorkerBox(c *gin.Context, cu *user.User, args *actionArgs) (string, game.ActionType, error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
 	a := g.SelectedArea()
-	na := struct {
-		Workers Workers `form:"workers"`
-	}{}
-
-	err := c.ShouldBind(&na)
+	var na adminWorkerBoxForm
+	err := args.bind(&na)
 	if err != nil {
 		return "", game.None, err
 	}
@@ -0,0 +1,91 @@
+package atf
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// areaChecks lists the reason-returning check of each action performed on an area.
+var areaChecks = []struct {
+	Action string
+	Check  func(*Player, *Area) error
+}{
+	{"pay-action-cost", func(p *Player, a *Area) error { return p.canPayActionCost() }},
+	{"pass", func(p *Player, a *Area) error { return p.canAct("pass", Actions) }},
+	{"build-city", (*Player).canBuildCityIn},
+	{"abandon-city", (*Player).canAbandonCityIn},
+	{"place-workers", (*Player).canPlaceWorkersIn},
+	{"use-scribe", (*Player).canUseScribe},
+	{"trade-resource", (*Player).canTradeIn},
+	{"make-tool", (*Player).canMakeToolIn},
+	{"start-empire", (*Player).canStartEmpireIn},
+	{"buy-armies", (*Player).canBuyArmiesForArmyIn},
+	{"equip-army", (*Player).canEquipArmyIn},
+	{"place-armies", (*Player).canPlaceArmyIn},
+	{"reinforce-army", (*Player).canReinforceArmyIn},
+	{"invade-area", (*Player).canInvade},
+	{"invade-area-warning", (*Player).canInvadeWarning},
+	{"destroy-city", (*Player).canDestroyCityIn},
+	{"expand-city", (*Player).canExpandCityIn},
+}
+
+type jAreaAction struct {
+	Action  string `json:"action"`
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// areaActions reports, for each action, whether the current user may perform it in an area
+// or the rule that prevents it.  The area is given by the area parameter or is the selected area.
+func (client *Client) areaActions(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		cu, err := client.User.Current(c)
+		if err != nil {
+			client.Log.Debugf(err.Error())
+		}
+
+		var p *Player
+		if cu != nil {
+			p = g.PlayerByUserID(cu.ID())
+		}
+		if p == nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not a player in this game."})
+			return
+		}
+
+		a := g.SelectedArea()
+		if name := c.Query("area"); name != "" {
+			a = nil
+			if aid := toAreaID(name); aid >= 0 && int(aid) < len(g.Areas) {
+				a = g.Areas[aid]
+			}
+		}
+		if a == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "No area selected."})
+			return
+		}
+
+		jas := make([]jAreaAction, len(areaChecks))
+		for i, ac := range areaChecks {
+			jas[i] = jAreaAction{Action: ac.Action, Allowed: true}
+			if err := ac.Check(p, a); err != nil {
+				jas[i] = jAreaAction{Action: ac.Action, Reason: err.Error()}
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"area":    a.Name(),
+			"actions": jas,
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package atf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/SlothNinja/game"
+	"github.com/SlothNinja/restful"
+	"github.com/SlothNinja/sn"
+	"github.com/SlothNinja/user"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const maxBatchSize = 20
+
+// batchRequest provides an ordered list of actions.
+// Each action provides the form values that would otherwise be posted to update, e.g.,
+// {"action": "select-area", "area": "babylon"}
+// A field posted more than once is given as a list, e.g.,
+// {"action": "trade-resource", "tool-traded-resource": ["wood", "metal"]}
+type batchRequest struct {
+	Actions []batchStep `json:"actions" binding:"required"`
+}
+
+// batchStep provides the form values of an action.
+type batchStep url.Values
+
+func (s *batchStep) UnmarshalJSON(bs []byte) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(bs, &fields)
+	if err != nil {
+		return err
+	}
+
+	values := make(batchStep, len(fields))
+	for k, raw := range fields {
+		var v string
+		if json.Unmarshal(raw, &v) == nil {
+			values[k] = []string{v}
+			continue
+		}
+
+		var vs []string
+		err = json.Unmarshal(raw, &vs)
+		if err != nil {
+			return fmt.Errorf("field %q must be a string or a list of strings", k)
+		}
+		values[k] = vs
+	}
+	*s = values
+	return nil
+}
+
+type batchError struct {
+	Step    int    `json:"step"`
+	Action  string `json:"action"`
+	Message string `json:"message"`
+}
+
+// batch applies an ordered list of actions to a copy of the game.
+// Either every action is applied and the result cached or saved, or no action is applied.
+func (client *Client) batch(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		cu, err := client.User.Current(c)
+		if err != nil {
+			client.Log.Debugf(err.Error())
+		}
+
+		var req batchRequest
+		err = c.ShouldBindJSON(&req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if l := len(req.Actions); l < 1 || l > maxBatchSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": sn.NewVError("A batch must provide between 1 and %d actions.", maxBatchSize).Error()})
+			return
+		}
+
+		g2, err := client.deepCopy(c, g)
+		if err != nil {
+			client.Log.Errorf(err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		actionType, berr := g2.applyBatch(c, cu, req.Actions)
+		if berr != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": berr})
+			return
+		}
+
+		switch actionType {
+		case game.Cache:
+			g2.recordOutcome(c, cu, "json", "")
+			client.Cache.SetDefault(g2.UndoKey(cu), g2)
+		case game.Save:
+			g2.recordOutcome(c, cu, "json", "")
+			err = client.save(c, g2, cu)
+			if errors.Is(err, ErrConflict) {
+				client.Log.Warningf(err.Error())
+				client.conflict(c, prefix)
+				return
+			}
+			if err != nil {
+				client.Log.Errorf(err.Error())
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"game":    g2,
+			"notices": restful.NoticesFrom(c),
+		})
+	}
+}
+
+// applyBatch applies each action in turn, stopping at the first action that fails.
+// The returned action type is Save if any action requires a save, and Cache otherwise.
+func (g *Game) applyBatch(c *gin.Context, cu *user.User, actions []batchStep) (game.ActionType, *batchError) {
+	result := game.None
+	for i, values := range actions {
+		a := url.Values(values).Get("action")
+		if d, ok := actionDefFor(a); ok && d.Role == adminRole {
+			return game.None, &batchError{Step: i, Action: a, Message: "Admin actions can not be batched."}
+		}
+
+		sc := stepContext(c, values)
+		_, actionType, err := g.Update(sc, cu)
+		for _, n := range restful.NoticesFrom(sc)[len(restful.NoticesFrom(c)):] {
+			restful.AddNoticef(c, "%s", n)
+		}
+
+		switch {
+		case err != nil:
+			return game.None, &batchError{Step: i, Action: a, Message: err.Error()}
+		case actionType == game.Undo:
+			return game.None, &batchError{Step: i, Action: a, Message: "Actions that undo can not be batched."}
+		case actionType == game.Save:
+			result = game.Save
+		case actionType == game.Cache && result != game.Save:
+			result = game.Cache
+		}
+	}
+	return result, nil
+}
+
+// stepContext returns a copy of c whose request posts the provided form values.
+func stepContext(c *gin.Context, values batchStep) *gin.Context {
+	body := url.Values(values).Encode()
+
+	r := c.Request.Clone(c.Request.Context())
+	r.Method = http.MethodPost
+	r.Body = ioutil.NopCloser(strings.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Set("Content-Type", binding.MIMEPOSTForm)
+	r.Form, r.PostForm, r.MultipartForm = nil, nil, nil
+
+	sc := c.Copy()
+	sc.Request = r
+	return sc
+}
@@ -0,0 +1,301 @@
+package atf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Region types of map areas.
+const (
+	workerBoxRegion = "worker-box"
+	sumerRegion     = "sumer"
+	nonSumerRegion  = "non-sumer"
+)
+
+// areaDef declares an area of a map.
+// Areas are identified by name and must be board areas (see areaIDS).  A map must define every board area,
+// and can not add areas, as the rules refer to areas (e.g., the worker boxes and Sippar) by id.
+// Adjacency is symmetric: each area listed as adjacent must list the area in turn,
+// unless the area is also listed as one way, in which case it must not.
+type areaDef struct {
+	Name     string   `json:"name"`
+	Region   string   `json:"region"`
+	Adjacent []string `json:"adjacent,omitempty"`
+	OneWay   []string `json:"oneWay,omitempty"`
+	Trade    []string `json:"trade,omitempty"`
+	Value    int      `json:"value,omitempty"`
+	Workers  int      `json:"workers,omitempty"`
+	Empire   bool     `json:"empire,omitempty"`
+	Decline  bool     `json:"decline,omitempty"`
+}
+
+// boardMap is a validated map definition.
+type boardMap struct {
+	Name  string    `json:"name"`
+	Areas []areaDef `json:"areas"`
+
+	defs      map[AreaID]areaDef
+	adjacent  map[AreaID]AreaIDS
+	trade     map[AreaID][]Resource
+	regionIDS map[string]AreaIDS
+	empires   AreaIDS
+	declines  AreaIDS
+	scoring   AreaIDS
+	trading   AreaIDS
+}
+
+const defaultMapName = "standard"
+
+var boardMaps = make(map[string]*boardMap)
+
+func init() {
+	if err := registerMap([]byte(standardMapJSON)); err != nil {
+		panic(err)
+	}
+}
+
+// mapFilesEnv lists, separated as in PATH, files of map definitions to register at startup.
+const mapFilesEnv = "ATF_MAP_FILES"
+
+// registerMapsFromEnv registers the maps defined in the files listed by mapFilesEnv.
+func registerMapsFromEnv() error {
+	v := os.Getenv(mapFilesEnv)
+	if v == "" {
+		return nil
+	}
+
+	for _, path := range filepath.SplitList(v) {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		err = registerMap(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// registerMap validates the provided map definition and makes it available as a variant.
+func registerMap(data []byte) error {
+	m, err := parseBoardMap(data)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := boardMaps[m.Name]; !ok {
+		for i := range variantOptions {
+			if variantOptions[i].Name == "map" {
+				variantOptions[i].Values = append(variantOptions[i].Values, m.Name)
+			}
+		}
+	}
+	boardMaps[m.Name] = m
+	return nil
+}
+
+func parseBoardMap(data []byte) (*boardMap, error) {
+	m := new(boardMap)
+	err := json.Unmarshal(data, m)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.validate()
+	if err != nil {
+		return nil, fmt.Errorf("map %q: %w", m.Name, err)
+	}
+	return m, nil
+}
+
+// validate checks the definition and indexes it.
+func (m *boardMap) validate() error {
+	if m.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+
+	m.defs = make(map[AreaID]areaDef, len(m.Areas))
+	for _, d := range m.Areas {
+		aid := toAreaID(d.Name)
+		switch {
+		case !areaIDS().include(aid):
+			return fmt.Errorf("%q is not a board area", d.Name)
+		case m.defs[aid].Name != "":
+			return fmt.Errorf("%q is defined twice", d.Name)
+		case d.Region != workerBoxRegion && d.Region != sumerRegion && d.Region != nonSumerRegion:
+			return fmt.Errorf("%q has unknown region %q", d.Name, d.Region)
+		case d.Value < 0 || d.Workers < 0:
+			return fmt.Errorf("%q has a negative value or workers", d.Name)
+		case d.Region == workerBoxRegion && (len(d.Adjacent) > 0 || d.Empire):
+			return fmt.Errorf("worker box %q can not be adjacent to areas or hold an empire", d.Name)
+		case d.Region != nonSumerRegion && len(d.Trade) > 0:
+			return fmt.Errorf("only non-sumer areas trade, but %q does", d.Name)
+		}
+		m.defs[aid] = d
+	}
+
+	for _, aid := range areaIDS() {
+		if _, ok := m.defs[aid]; !ok {
+			return fmt.Errorf("%q is not defined", aid)
+		}
+	}
+
+	m.adjacent = make(map[AreaID]AreaIDS, len(m.defs))
+	m.trade = make(map[AreaID][]Resource, len(m.defs))
+	m.regionIDS = make(map[string]AreaIDS)
+	m.empires, m.declines, m.scoring, m.trading = nil, nil, nil, nil
+	for _, aid := range areaIDS() {
+		d := m.defs[aid]
+		for _, name := range d.Adjacent {
+			adj := toAreaID(name)
+			switch {
+			case !areaIDS().include(adj):
+				return fmt.Errorf("%q is adjacent to unknown area %q", d.Name, name)
+			case adj == aid:
+				return fmt.Errorf("%q is adjacent to itself", d.Name)
+			case m.defs[adj].Region == workerBoxRegion:
+				return fmt.Errorf("%q is adjacent to worker box %q", d.Name, name)
+			case m.adjacent[aid].include(adj):
+				return fmt.Errorf("%q lists %q as adjacent twice", d.Name, name)
+			}
+			m.adjacent[aid] = append(m.adjacent[aid], adj)
+		}
+
+		for _, name := range d.OneWay {
+			if !d.adjacent(toAreaID(name)) {
+				return fmt.Errorf("%q lists %q as one way, but not as adjacent", d.Name, name)
+			}
+		}
+
+		for _, name := range d.Trade {
+			r := toResource(name)
+			if r == noResource {
+				return fmt.Errorf("%q trades unknown resource %q", d.Name, name)
+			}
+			m.trade[aid] = append(m.trade[aid], r)
+		}
+
+		m.regionIDS[d.Region] = append(m.regionIDS[d.Region], aid)
+		if d.Empire {
+			m.empires = append(m.empires, aid)
+		}
+		if d.Decline {
+			m.declines = append(m.declines, aid)
+		}
+		if d.Value > 0 {
+			m.scoring = append(m.scoring, aid)
+		}
+		if len(d.Trade) > 0 {
+			m.trading = append(m.trading, aid)
+		}
+	}
+
+	for _, aid := range areaIDS() {
+		d := m.defs[aid]
+		for _, adj := range m.adjacent[aid] {
+			name := m.defs[adj].Name
+			switch back := m.defs[adj].adjacent(aid); {
+			case d.oneWay(adj) && back:
+				return fmt.Errorf("%q lists %q as one way, but %q lists %q as adjacent", d.Name, name, name, d.Name)
+			case !d.oneWay(adj) && !back:
+				return fmt.Errorf("%q lists %q as adjacent, but %q does not list %q", d.Name, name, name, d.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (d areaDef) adjacent(aid AreaID) bool {
+	return namesInclude(d.Adjacent, aid)
+}
+
+func (d areaDef) oneWay(aid AreaID) bool {
+	return namesInclude(d.OneWay, aid)
+}
+
+func namesInclude(names []string, aid AreaID) bool {
+	for _, name := range names {
+		if toAreaID(name) == aid {
+			return true
+		}
+	}
+	return false
+}
+
+// boardMap returns the map of the game.
+func (g *Game) boardMap() *boardMap {
+	if m, ok := boardMaps[g.variant()["map"]]; ok {
+		return m
+	}
+	return boardMaps[defaultMapName]
+}
+
+func (g *Game) workerBoxIDS() AreaIDS {
+	return g.boardMap().regionIDS[workerBoxRegion]
+}
+
+func (g *Game) sumerIDS() AreaIDS {
+	return g.boardMap().regionIDS[sumerRegion]
+}
+
+func (g *Game) nonSumerIDS() AreaIDS {
+	return g.boardMap().regionIDS[nonSumerRegion]
+}
+
+func (g *Game) empireIDS() AreaIDS {
+	return g.boardMap().empires
+}
+
+func (g *Game) declineIDS() AreaIDS {
+	return g.boardMap().declines
+}
+
+func (g *Game) scoringIDS() AreaIDS {
+	return g.boardMap().scoring
+}
+
+func (g *Game) tradeIDS() AreaIDS {
+	return g.boardMap().trading
+}
+
+const standardMapJSON = `{
+	"name": "standard",
+	"areas": [
+		{"name": "Irrigation", "region": "worker-box", "value": 3, "workers": 1, "decline": true},
+		{"name": "Weaving", "region": "worker-box", "value": 4, "workers": 1, "decline": true},
+		{"name": "Scribes", "region": "worker-box", "decline": true},
+		{"name": "NewScribes", "region": "worker-box"},
+		{"name": "UsedScribes", "region": "worker-box"},
+		{"name": "ToolMakers", "region": "worker-box", "decline": true},
+		{"name": "UsedToolMakers", "region": "worker-box"},
+
+		{"name": "Sippar", "region": "sumer", "empire": true, "adjacent": ["Amorites", "Assyria", "Babylon"]},
+		{"name": "Babylon", "region": "sumer", "empire": true, "adjacent": ["Amorites", "Assyria", "Sippar", "Akkad", "Kassites", "Shuruppak", "Nippur"], "oneWay": ["Akkad"]},
+		{"name": "Nippur", "region": "sumer", "empire": true, "adjacent": ["Babylon", "Kassites", "Guti", "Umma", "Shuruppak"]},
+		{"name": "Shuruppak", "region": "sumer", "empire": true, "adjacent": ["Isin", "Babylon", "Nippur", "Umma", "Uruk"], "oneWay": ["Isin"]},
+		{"name": "Umma", "region": "sumer", "empire": true, "adjacent": ["Nippur", "Guti", "Elam", "Lagash", "Ur", "Uruk", "Shuruppak"]},
+		{"name": "Uruk", "region": "sumer", "empire": true, "adjacent": ["Larsa", "Shuruppak", "Umma", "Ur"], "oneWay": ["Larsa"]},
+		{"name": "Ur", "region": "sumer", "empire": true, "adjacent": ["Chaldea", "Uruk", "Umma", "Lagash", "Eridu"], "oneWay": ["Chaldea"]},
+		{"name": "Lagash", "region": "sumer", "empire": true, "adjacent": ["Umma", "Elam", "Eridu", "Ur"]},
+		{"name": "Eridu", "region": "sumer", "empire": true, "adjacent": ["Ur", "Lagash"]},
+
+		{"name": "Egypt", "region": "non-sumer", "empire": true, "decline": true, "value": 4, "trade": ["gold"], "adjacent": ["Amorites"]},
+		{"name": "Amorites", "region": "non-sumer", "empire": true, "decline": true, "value": 4, "trade": ["wood", "oil"], "adjacent": ["Egypt", "Hittites", "Mittani", "Assyria", "Sippar", "Babylon"]},
+		{"name": "Hittites", "region": "non-sumer", "empire": true, "decline": true, "value": 3, "trade": ["metal"], "adjacent": ["Mittani", "Amorites"]},
+		{"name": "Mittani", "region": "non-sumer", "empire": true, "decline": true, "value": 3, "trade": ["wood", "metal"], "adjacent": ["Hittites", "Amorites", "Assyria"]},
+		{"name": "Assyria", "region": "non-sumer", "empire": true, "decline": true, "value": 2, "adjacent": ["Amorites", "Mittani", "Kassites", "Sippar", "Babylon"]},
+		{"name": "Kassites", "region": "non-sumer", "empire": true, "decline": true, "value": 4, "trade": ["lapis"], "adjacent": ["Assyria", "Babylon", "Nippur"]},
+		{"name": "Guti", "region": "non-sumer", "empire": true, "decline": true, "value": 2, "adjacent": ["Nippur", "Umma"]},
+		{"name": "Elam", "region": "non-sumer", "empire": true, "decline": true, "value": 3, "trade": ["metal"], "adjacent": ["Umma", "Lagash"]},
+		{"name": "Dilmun", "region": "non-sumer", "decline": true, "value": 2, "trade": ["wood", "metal", "oil", "gold"]},
+		{"name": "Chaldea", "region": "non-sumer", "empire": true, "decline": true, "value": 3, "trade": ["oil"]},
+		{"name": "Larsa", "region": "non-sumer", "empire": true, "decline": true},
+		{"name": "Isin", "region": "non-sumer", "empire": true, "decline": true},
+		{"name": "Akkad", "region": "non-sumer", "empire": true, "decline": true}
+	]
+}`
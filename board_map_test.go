@@ -0,0 +1,72 @@
+package atf
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBoardMapValidate(t *testing.T) {
+	// def returns the definition of the named area of m
+	def := func(m *boardMap, name string) *areaDef {
+		for i := range m.Areas {
+			if m.Areas[i].Name == name {
+				return &m.Areas[i]
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name   string
+		change func(*boardMap)
+		// wantErr is a substring of the expected error, if any
+		wantErr string
+	}{
+		{"standard", func(m *boardMap) {}, ""},
+		{"missing name", func(m *boardMap) { m.Name = "" }, "missing name"},
+		{"unknown area", func(m *boardMap) { m.Areas[0].Name = "Atlantis" }, "is not a board area"},
+		{"area defined twice", func(m *boardMap) { m.Areas = append(m.Areas, m.Areas[0]) }, "is defined twice"},
+		{"area missing", func(m *boardMap) { m.Areas = m.Areas[1:] }, "is not defined"},
+		{"unknown region", func(m *boardMap) { def(m, "Ur").Region = "sea" }, "unknown region"},
+		{"sumer trades", func(m *boardMap) { def(m, "Ur").Trade = []string{"oil"} }, "only non-sumer areas trade"},
+		{"unknown resource", func(m *boardMap) { def(m, "Egypt").Trade = []string{"silk"} }, "unknown resource"},
+		{"adjacent to itself", func(m *boardMap) {
+			d := def(m, "Eridu")
+			d.Adjacent = append(d.Adjacent, "Eridu")
+		}, "adjacent to itself"},
+		{"adjacent to worker box", func(m *boardMap) {
+			d := def(m, "Eridu")
+			d.Adjacent = append(d.Adjacent, "Irrigation")
+		}, "adjacent to worker box"},
+		{"asymmetric", func(m *boardMap) {
+			d := def(m, "Eridu")
+			d.Adjacent = append(d.Adjacent, "Umma")
+		}, `"Eridu" lists "Umma" as adjacent, but "Umma" does not list "Eridu"`},
+		{"one way", func(m *boardMap) {
+			d := def(m, "Eridu")
+			d.Adjacent, d.OneWay = append(d.Adjacent, "Umma"), []string{"Umma"}
+		}, ""},
+		{"one way not adjacent", func(m *boardMap) { def(m, "Eridu").OneWay = []string{"Umma"} }, "as one way, but not as adjacent"},
+		{"one way reciprocated", func(m *boardMap) { def(m, "Eridu").OneWay = []string{"Ur"} }, `"Ur" lists "Eridu" as adjacent`},
+		{"one way removed", func(m *boardMap) { def(m, "Ur").OneWay = nil }, `"Ur" lists "Chaldea" as adjacent, but "Chaldea" does not`},
+	}
+
+	for _, test := range tests {
+		m := new(boardMap)
+		if err := json.Unmarshal([]byte(standardMapJSON), m); err != nil {
+			t.Fatal(err)
+		}
+		test.change(m)
+
+		err := m.validate()
+		switch {
+		case test.wantErr == "" && err != nil:
+			t.Errorf("%s: validate() = %v", test.name, err)
+		case test.wantErr != "" && err == nil:
+			t.Errorf("%s: validate() succeeded; want error containing %q", test.name, test.wantErr)
+		case test.wantErr != "" && !strings.Contains(err.Error(), test.wantErr):
+			t.Errorf("%s: validate() = %v; want error containing %q", test.name, err, test.wantErr)
+		}
+	}
+}
@@ -18,21 +18,17 @@ func init() {
 	gob.Register(new(abandonCityEntry))
 }
 
-func (g *Game) buildCity(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) buildCity(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validateBuildCity(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
 	cp := g.CurrentPlayer()
 	area := g.SelectedArea()
 	area.City.Built = true
 	area.City.OwnerID = cp.ID()
 	cp.City -= 1
 	g.BuiltCityAreaID = area.ID
+	g.emit(cp, &CityBuilt{Area: area.Name()})
 
 	// Log Placement
 	e1 := cp.newBuildCityEntry()
@@ -50,39 +46,37 @@ func (g *Game) buildCity(c *gin.Context, cu *user.User) (tmpl string, act game.A
 	} else {
 		cp.PerformedAction = true
 	}
-	tmpl, act = "atf/cities_update", game.Cache
+	act = game.Cache
 	return
 }
 
 func (p *Player) collectPrivilge(a *Area) *cityPrivilegeEntry {
-	switch a.ID {
-	case Eridu:
-		return p.newCityPrivilegeEntry(a, p.collectEriduPrivilege())
-	case Uruk:
-		return p.newCityPrivilegeEntry(a, p.collectUrukPrivilege())
+	if priv, ok := p.Game().privilegeIn(a.ID); ok && priv.Trigger == onBuild {
+		return p.newCityPrivilegeEntry(a, priv.Name, priv.Build(p))
 	}
 	return nil
 }
 
 type cityPrivilegeEntry struct {
 	*Entry
-	AreaID AreaID
-	Reason int
+	AreaID    AreaID
+	Privilege string
+	Reason    int
 }
 
-func (p *Player) newCityPrivilegeEntry(a *Area, reason int) *cityPrivilegeEntry {
+func (p *Player) newCityPrivilegeEntry(a *Area, privilege string, reason int) *cityPrivilegeEntry {
 	g := p.Game()
 	e := &cityPrivilegeEntry{
-		Entry:  p.newEntry(),
-		AreaID: a.ID,
-		Reason: reason,
+		Entry:     p.newEntry(),
+		AreaID:    a.ID,
+		Privilege: privilege,
+		Reason:    reason,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
 
-func (p *Player) collectEriduPrivilege() int {
+func (p *Player) collectToolmakerPrivilege() int {
 	g := p.Game()
 	cp := g.CurrentPlayer()
 	if cp.WorkerSupply < 1 {
@@ -94,7 +88,7 @@ func (p *Player) collectEriduPrivilege() int {
 	return 0
 }
 
-func (p *Player) collectUrukPrivilege() int {
+func (p *Player) collectScribePrivilege() int {
 	g := p.Game()
 	cp := g.CurrentPlayer()
 	switch {
@@ -109,37 +103,36 @@ func (p *Player) collectUrukPrivilege() int {
 	return 0
 }
 
-func (p *Player) receivedBabylonPrivilege() int {
-	if owner := p.Game().Areas[Babylon].City.Owner(); owner != nil && p.Game().CurrentPlayer().Equal(owner) {
-		return 2
-	}
-	return 0
-}
-
 func (e *cityPrivilegeEntry) HTML() template.HTML {
 	name := e.Player().Name()
-	switch e.AreaID {
-	case Eridu:
+	privilege := e.Privilege
+	if privilege == "" {
+		// entries logged before privileges were registered
+		privilege = privilegeSets[defaultPrivilegeSet][e.AreaID]
+	}
+
+	switch privilege {
+	case "Toolmaker":
 		switch e.Reason {
 		case 1:
-			return restful.HTML("%s did not receive a toolmaker for city in Eridu for lack of workers.", name)
+			return restful.HTML("%s did not receive a toolmaker for city in %s for lack of workers.", name, e.AreaID)
 		default:
-			return restful.HTML("%s received a toolmaker for city in Eridu.", name)
+			return restful.HTML("%s received a toolmaker for city in %s.", name, e.AreaID)
 		}
-	case Uruk:
+	case "Scribe":
 		switch e.Reason {
 		case 1:
-			return restful.HTML("%s did not receive a scribe for city in Uruk for lack of workers.", name)
+			return restful.HTML("%s did not receive a scribe for city in %s for lack of workers.", name, e.AreaID)
 		case 2:
-			return restful.HTML("%s did not receive a scribe for city in Uruk for already being at scribe limit.", name)
+			return restful.HTML("%s did not receive a scribe for city in %s for already being at scribe limit.", name, e.AreaID)
 		default:
-			return restful.HTML("%s received a scribe for city in Uruk.", name)
+			return restful.HTML("%s received a scribe for city in %s.", name, e.AreaID)
 		}
 	}
 	return ""
 }
 
-func (g *Game) validateBuildCity(c *gin.Context, cu *user.User) (err error) {
+func (g *Game) validateBuildCity(cu *user.User, args *actionArgs) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
@@ -148,12 +141,10 @@ func (g *Game) validateBuildCity(c *gin.Context, cu *user.User) (err error) {
 		cp *Player
 	)
 
-	switch a, cp, err = g.SelectedArea(), g.CurrentPlayer(), g.validatePlayerAction(cu); {
+	switch a, cp, err = g.SelectedArea(), g.CurrentPlayer(), g.validateMultiAction(cu, "build-city"); {
 	case err != nil:
 	case a == nil:
 		err = sn.NewVError("No area selected.")
-	case cp.PerformedAction:
-		err = sn.NewVError("You have already performed an action.")
 	case !a.IsSumer():
 		err = sn.NewVError("%s is not a Sumer area.", a.Name())
 	case a.City.Built:
@@ -176,7 +167,6 @@ func (p *Player) newBuildCityEntry() *buildCityEntry {
 		Entry:    p.newEntry(),
 		AreaName: g.SelectedArea().Name(),
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -185,15 +175,10 @@ func (e *buildCityEntry) HTML() template.HTML {
 	return restful.HTML("%s built a city in %s.", e.Player().Name(), e.AreaName)
 }
 
-func (g *Game) abandonCity(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) abandonCity(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validateAbandonCity(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
 	cp := g.CurrentPlayer()
 	cp.City += 1
 
@@ -205,28 +190,27 @@ func (g *Game) abandonCity(c *gin.Context, cu *user.User) (tmpl string, act game
 	area.City = newCity(area)
 	g.MultiAction = noMultiAction
 	cp.PerformedAction = true
+	g.emit(cp, &CityAbandoned{Area: area.Name()})
 
 	// Log Placement
 	e := cp.newAbandonCityEntry()
 	restful.AddNoticef(c, string(e.HTML()))
 
-	tmpl, act = "atf/cities_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateAbandonCity(c *gin.Context, cu *user.User) (err error) {
+func (g *Game) validateAbandonCity(cu *user.User, args *actionArgs) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validatePlayerAction(cu); err != nil {
+	if err = g.validateMultiAction(cu, "abandon-city"); err != nil {
 		return
 	}
 
 	switch a, cp := g.SelectedArea(), g.CurrentPlayer(); {
 	case a == nil:
 		err = sn.NewVError("No area selected.")
-	case cp.PerformedAction:
-		err = sn.NewVError("You have already performed an action.")
 	case !a.IsSumer():
 		err = sn.NewVError("%s is not a Sumer area.", a.Name())
 	case !a.City.Built:
@@ -248,7 +232,6 @@ func (p *Player) newAbandonCityEntry() *abandonCityEntry {
 		Entry:    p.newEntry(),
 		AreaName: g.SelectedArea().Name(),
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -23,17 +23,13 @@ type armyResources struct {
 	Tool  int `form:"tool"`
 }
 
-func (g *Game) buyArmies(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) buyArmies(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	buyArmyResources, bought, err := g.validateBuyArmies(c, cu)
-	if err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
 	cp := g.CurrentPlayer()
+	buyArmyResources := args.Resources
+	bought := cp.armiesBought(buyArmyResources)
 	cp.Army += bought
 	cp.ArmySupply -= bought
 	for resource, count := range buyArmyResources {
@@ -46,55 +42,37 @@ func (g *Game) buyArmies(c *gin.Context, cu *user.User) (tmpl string, act game.A
 	e3 := cp.newBuyArmiesEntry(buyArmyResources, bought)
 	restful.AddNoticef(c, string(e3.HTML()))
 
-	tmpl, act = "atf/buy_armies_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateBuyArmies(c *gin.Context, cu *user.User) (resources Resources, bought int, err error) {
+func (g *Game) validateBuyArmies(cu *user.User, args *actionArgs) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validatePlayerAction(cu); err != nil {
+	if err = g.validateMultiAction(cu, "buy-armies"); err != nil {
 		return
 	}
 
 	cp := g.CurrentPlayer()
-	if cp.PerformedAction {
-		err = sn.NewVError("You have already performed an action.")
-		return
-	}
-
-	rs := struct {
-		Grain int `form:"grain"`
-		Metal int `form:"metal"`
-		Tool  int `form:"tool"`
-	}{}
-	err = c.ShouldBind(&rs)
-	if err != nil {
-		return nil, 0, err
-	}
-	// rs := new(armyResources)
-	// if err = restful.BindWith(c, rs, binding.FormPost); err != nil {
-	// 	return
-	// }
-
-	resources = make(Resources, 8)
-	resources[Grain] = rs.Grain
-	resources[Metal] = rs.Metal
-	resources[Tool] = rs.Tool
-
-	for resource, value := range resourceArmyValueMap {
-		count := resources[resource]
+	for resource := range resourceArmyValueMap {
+		count := args.Resources[resource]
 		if count > cp.Resources[resource] {
-			return nil, 0, sn.NewVError("You do not have %d %s.", count, resource)
+			return sn.NewVError("You do not have %d %s.", count, resource)
 		}
-		bought += count * value
 	}
+	return
+}
 
-	if bought > cp.ArmySupply {
-		bought = cp.ArmySupply
+// armiesBought returns the number of armies p buys with resources, limited by the army supply.
+func (p *Player) armiesBought(resources Resources) (bought int) {
+	for resource, value := range resourceArmyValueMap {
+		bought += resources[resource] * value
 	}
 
+	if bought > p.ArmySupply {
+		bought = p.ArmySupply
+	}
 	return
 }
 
@@ -111,7 +89,6 @@ func (p *Player) newBuyArmiesEntry(resources Resources, bought int) *buyArmiesEn
 		ArmyResources: resources,
 		Bought:        bought,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -84,7 +84,6 @@ func (p *Player) newCollectGrainEntry(grain int) *collectGrainEntry {
 		Entry: p.newEntry(),
 		Grain: grain,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -104,14 +103,20 @@ func (g *Game) collectTextilePhase(c *gin.Context) {
 }
 
 func (p *Player) collectTextile() {
-	textile := p.textileIncome()
-	if textile > 0 && p.hasCityIn(Ur) {
-		textile += 1
-	}
+	textile := p.textileCollected()
 	p.Resources[Textile] += textile
 	p.newCollectTextileEntry(textile)
 }
 
+// textileCollected returns the textile income of p, including any income privilege.
+func (p *Player) textileCollected() int {
+	textile := p.textileIncome()
+	if textile > 0 {
+		textile += p.privilegeBonus(onIncome)
+	}
+	return textile
+}
+
 func (p *Player) textileIncome() int {
 	wBox := p.Game().Areas[Weaving]
 	w := wBox.Workers[p.ID()]
@@ -164,7 +169,6 @@ func (p *Player) newCollectTextileEntry(textile int) *collectTextileEntry {
 		Entry:   p.newEntry(),
 		Textile: textile,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -207,7 +211,6 @@ func (p *Player) newCollectWorkersEntry(workers int) *collectWorkersEntry {
 		Entry:   p.newEntry(),
 		Workers: workers,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
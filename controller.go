@@ -58,68 +58,29 @@ func (g *Game) Update(c *gin.Context, cu *user.User) (tmpl string, t game.Action
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	switch a := c.PostForm("action"); a {
-	case "select-area":
-		return g.selectArea(c, cu)
-	case "build-city":
-		return g.buildCity(c, cu)
-	case "buy-armies":
-		return g.buyArmies(c, cu)
-	case "equip-army":
-		return g.equipArmy(c, cu)
-	case "place-armies":
-		return g.placeArmies(c, cu)
-	case "place-workers":
-		return g.placeWorkers(c, cu)
-	case "trade-resource":
-		return g.tradeResource(c, cu)
-	case "use-scribe":
-		return g.useScribe(c, cu)
-	case "from-stock":
-		return g.fromStock(c, cu)
-	case "make-tool":
-		return g.makeTool(c, cu)
-	case "start-empire":
-		return g.startEmpire(c, cu)
-	case "cancel-start-empire":
-		return g.cancelStartEmpire(c, cu)
-	case "confirm-start-empire":
-		return g.confirmStartEmpire(c, cu)
-	case "invade-area":
-		return g.invadeArea(c, cu)
-	case "invade-area-warning":
-		return g.invadeAreaWarning(c, cu)
-	case "cancel-invasion":
-		return g.cancelInvasion(c, cu)
-	case "confirm-invasion":
-		return g.confirmInvasion(c, cu)
-	case "reinforce-army":
-		return g.reinforceArmy(c, cu)
-	case "destroy-city":
-		return g.destroyCity(c, cu)
-	case "pass":
-		return g.pass(c, cu)
-	case "pay-action-cost":
-		return g.payActionCost(c, cu)
-	case "expand-city":
-		return g.expandCity(c, cu)
-	case "abandon-city":
-		return g.abandonCity(c, cu)
-	case "admin-header":
-		return g.adminHeader(c, cu)
-	case "admin-sumer-area":
-		return g.adminSumerArea(c, cu)
-	case "admin-non-sumer-area":
-		return g.adminNonSumerArea(c, cu)
-	case "admin-worker-box":
-		return g.adminWorkerBox(c, cu)
-	case "admin-player":
-		return g.adminPlayer(c, cu)
-	case "admin-supply-table":
-		return g.adminSupplyTable(c, cu)
-	default:
-		return "atf/flash_notice", game.None, sn.NewVError("%v is not a valid action.", a)
+	d, err := actionFrom(c)
+	if err != nil {
+		return "atf/flash_notice", game.None, err
 	}
+	return d.perform(g, c, cu)
+}
+
+// tryUpdate performs the posted action like Update, but is not counted in the action stats.
+func (g *Game) tryUpdate(c *gin.Context, cu *user.User) (string, game.ActionType, error) {
+	d, err := actionFrom(c)
+	if err != nil {
+		return "atf/flash_notice", game.None, err
+	}
+	return d.try(g, c, cu)
+}
+
+func actionFrom(c *gin.Context) (*actionDef, error) {
+	a := c.PostForm("action")
+	d, ok := actionDefFor(a)
+	if !ok {
+		return nil, sn.NewVError("%v is not a valid action.", a)
+	}
+	return d, nil
 }
 
 func newGamer(c *gin.Context) game.Gamer {
@@ -148,11 +109,20 @@ func (client *Client) show(prefix string) gin.HandlerFunc {
 			client.Log.Debugf(err.Error())
 		}
 
+		g := gameFrom(c)
+		gl, err := client.recentLog(c, g, defaultLogLimit)
+		if err != nil {
+			client.Log.Errorf(err.Error())
+			return
+		}
+
 		c.HTML(http.StatusOK, prefix+"/show", gin.H{
 			"Context":    c,
 			"VersionID":  sn.VersionID(),
 			"CUser":      cu,
-			"Game":       gameFrom(c),
+			"Game":       g,
+			"Variant":    g.Variant(),
+			"Log":        gl,
 			"IsAdmin":    cu.IsAdmin(),
 			"Admin":      game.AdminFrom(c),
 			"MessageLog": ml,
@@ -229,10 +199,20 @@ func (client *Client) update(prefix string) gin.HandlerFunc {
 			c.Redirect(http.StatusSeeOther, homePath)
 			return
 		case actionType == game.Cache:
+			g.recordOutcome(c, cu, template, updateLocation(prefix, c, template))
 			mkey := g.UndoKey(cu)
 			client.Cache.SetDefault(mkey, g)
 		case actionType == game.Save:
+			g.recordOutcome(c, cu, template, updateLocation(prefix, c, template))
 			err = client.save(c, g, cu)
+			if err != nil {
+				g.forgetOutcome(c, cu)
+			}
+			if errors.Is(err, ErrConflict) {
+				client.Log.Warningf(err.Error())
+				client.conflict(c, prefix)
+				return
+			}
 			if err != nil {
 				client.Log.Errorf(err.Error())
 				c.Redirect(http.StatusSeeOther, showPath(prefix, c.Param(hParam)))
@@ -262,6 +242,15 @@ func (client *Client) update(prefix string) gin.HandlerFunc {
 		}
 	}
 }
+
+// updateLocation returns the location to which update redirects for the template, if any.
+func updateLocation(prefix string, c *gin.Context, template string) string {
+	if template == "" {
+		return showPath(prefix, c.Param(hParam))
+	}
+	return ""
+}
+
 func (client *Client) new(prefix string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		client.Log.Debugf(msgEnter)
@@ -309,6 +298,14 @@ func (client *Client) create(prefix string) gin.HandlerFunc {
 			return
 		}
 
+		err = g.variantFromForm(c)
+		if err != nil {
+			client.Log.Errorf(err.Error())
+			restful.AddErrorf(c, err.Error())
+			c.Redirect(http.StatusSeeOther, recruitingPath(prefix))
+			return
+		}
+
 		g.NumPlayers = 3
 		err = g.encode(c)
 		if err != nil {
@@ -381,7 +378,16 @@ func (client *Client) accept(prefix string) gin.HandlerFunc {
 			}
 		}
 
+		g.recordOutcome(c, cu, "", recruitingPath(prefix))
 		err = client.save(c, g, cu)
+		if err != nil {
+			g.forgetOutcome(c, cu)
+		}
+		if errors.Is(err, ErrConflict) {
+			client.Log.Warningf(err.Error())
+			client.conflict(c, prefix)
+			return
+		}
 		if err != nil {
 			client.Log.Errorf(err.Error())
 			restful.AddErrorf(c, err.Error())
@@ -425,7 +431,16 @@ func (client *Client) drop(prefix string) gin.HandlerFunc {
 			return
 		}
 
+		g.recordOutcome(c, cu, "", recruitingPath(prefix))
 		err = client.save(c, g, cu)
+		if err != nil {
+			g.forgetOutcome(c, cu)
+		}
+		if errors.Is(err, ErrConflict) {
+			client.Log.Warningf(err.Error())
+			client.conflict(c, prefix)
+			return
+		}
 		if err != nil {
 			client.Log.Errorf(err.Error())
 			restful.AddErrorf(c, err.Error())
@@ -512,30 +527,50 @@ func (client *Client) dsGet(c *gin.Context, g *Game) error {
 	client.Log.Debugf(msgEnter)
 	defer client.Log.Debugf(msgExit)
 
-	err := client.DS.Get(c, g.Key, g.Header)
-	switch {
-	case err != nil:
-		restful.AddErrorf(c, err.Error())
-		return err
-	case g == nil:
-		err = fmt.Errorf("Unable to get game for id: %v", g.ID)
-		restful.AddErrorf(c, err.Error())
-		return err
+	found := false
+	if isIdempotent(c) {
+		g2, ok, err := client.fromCache(c, g.ID())
+		if err != nil {
+			client.Log.Warningf("unable to use cached game %d: %v", g.ID(), err)
+		}
+		if ok {
+			g, found = g2, true
+		}
 	}
 
-	s := newState()
-	err = codec.Decode(&s, g.SavedState)
-	if err != nil {
-		restful.AddErrorf(c, err.Error())
-		return err
-	}
-	g.State = s
+	if !found {
+		err := client.getHeader(c, g)
+		switch {
+		case err != nil:
+			restful.AddErrorf(c, err.Error())
+			return err
+		case g == nil:
+			err = fmt.Errorf("Unable to get game for id: %v", g.ID)
+			restful.AddErrorf(c, err.Error())
+			return err
+		}
 
-	err = client.init(c, g)
-	if err != nil {
-		restful.AddErrorf(c, err.Error())
-		return err
+		s := newState()
+		err = codec.Decode(&s, g.SavedState)
+		if err != nil {
+			restful.AddErrorf(c, err.Error())
+			return err
+		}
+		g.State = s
+
+		err = client.init(c, g)
+		if err != nil {
+			restful.AddErrorf(c, err.Error())
+			return err
+		}
+
+		if isIdempotent(c) {
+			if err := client.cacheGame(c, g); err != nil {
+				client.Log.Warningf("unable to cache game %d: %v", g.ID(), err)
+			}
+		}
 	}
+
 	cu, err := client.User.Current(c)
 	if err != nil {
 		client.Log.Debugf(err.Error())
@@ -572,6 +607,11 @@ func newPath(prefix string) string {
 }
 
 func (client *Client) save(c *gin.Context, g *Game, cu *user.User) error {
+	return client.saveWith(c, g, cu, nil, nil)
+}
+
+func (client *Client) saveWith(c *gin.Context, g *Game, cu *user.User, ks []*datastore.Key, es []interface{}) error {
+	base, pending, count, events := g.LogLength, g.Log, g.SnapshotCount, g.Events
 	_, err := client.DS.RunInTransaction(c, func(tx *datastore.Transaction) error {
 		oldG := New(c, g.ID())
 		err := tx.Get(oldG.Key, oldG.Header)
@@ -580,46 +620,32 @@ func (client *Client) save(c *gin.Context, g *Game, cu *user.User) error {
 		}
 
 		if oldG.UpdatedAt != g.UpdatedAt {
-			return fmt.Errorf("Game state changed unexpectantly.  Try again.")
-		}
-
-		err = g.encode(c)
-		if err != nil {
-			return err
+			return ErrConflict
 		}
 
-		_, err = tx.Put(g.Key, g.Header)
+		lks, les, err := logPagesFor(tx, g, base, pending)
 		if err != nil {
 			return err
 		}
 
-		client.Cache.Delete(g.UndoKey(cu))
-		return nil
-	})
-	return err
-}
-
-func (client *Client) saveWith(c *gin.Context, g *Game, cu *user.User, ks []*datastore.Key, es []interface{}) error {
-	_, err := client.DS.RunInTransaction(c, func(tx *datastore.Transaction) error {
-		oldG := New(c, g.ID())
-		err := tx.Get(oldG.Key, oldG.Header)
-		if err != nil {
-			return err
-		}
-
-		if oldG.UpdatedAt != g.UpdatedAt {
-			return fmt.Errorf("Game state changed unexpectantly.  Try again.")
+		g.Log, g.LogLength, g.Events = nil, base+len(pending), nil
+		due := g.snapshotDue(oldG.Header, base)
+		if due {
+			g.SnapshotCount = count + 1
 		}
-
 		err = g.encode(c)
 		if err != nil {
 			return err
 		}
 
-		ks = append(ks, g.Key)
-		es = append(es, g.Header)
+		lks, les = append(lks, ks...), append(les, es...)
+		if due {
+			s := newSnapshot(g)
+			lks, les = append(lks, s.Key), append(les, s)
+		}
+		lks, les = append(lks, g.Key), append(les, g.Header)
 
-		_, err = tx.PutMulti(ks, es)
+		_, err = tx.PutMulti(lks, les)
 		if err != nil {
 			return err
 		}
@@ -627,20 +653,21 @@ func (client *Client) saveWith(c *gin.Context, g *Game, cu *user.User, ks []*dat
 		client.Cache.Delete(g.UndoKey(cu))
 		return nil
 	})
-	return err
-}
-
-func wrap(s *user.Stats, cs []*contest.Contest) ([]*datastore.Key, []interface{}) {
-	l := len(cs) + 1
-	es := make([]interface{}, l)
-	ks := make([]*datastore.Key, l)
-	es[0] = s
-	ks[0] = s.Key
-	for i, c := range cs {
-		es[i+1] = c
-		ks[i+1] = c.Key
+	// invalidate after the transaction, so reads during it can not cache the replaced version
+	client.uncacheGame(g)
+	if err != nil {
+		g.Log, g.LogLength, g.SnapshotCount, g.Events = pending, base, count, events
+		return err
 	}
-	return ks, es
+
+	client.publish(c, g, events)
+	return nil
+}
+
+// wrap returns the keys and entities storing s and the contests cs of a game played with v.
+func wrap(s *user.Stats, v variant, cs []*contest.Contest) ([]*datastore.Key, []interface{}) {
+	ks, es := v.contestEntities(cs)
+	return append([]*datastore.Key{s.Key}, ks...), append([]interface{}{s}, es...)
 }
 
 func (g *Game) encode(c *gin.Context) (err error) {
@@ -2,6 +2,7 @@ package atf
 
 import (
 	"cloud.google.com/go/datastore"
+	"github.com/SlothNinja/codec"
 	"github.com/SlothNinja/game"
 	gtype "github.com/SlothNinja/type"
 	"github.com/gin-gonic/gin"
@@ -42,6 +43,7 @@ func (client *Client) init(c *gin.Context, g *Game) error {
 
 	for _, player := range g.Players() {
 		player.Init(g)
+		player.openLedger()
 	}
 
 	g.initAreas()
@@ -61,3 +63,54 @@ func copyGame(g Game) *Game {
 	g1 := g
 	return &g1
 }
+
+// deepCopy returns a copy of g that shares no mutable state with g.
+func (client *Client) deepCopy(c *gin.Context, g *Game) (*Game, error) {
+	encoded, err := codec.Encode(g.State)
+	if err != nil {
+		return nil, err
+	}
+
+	g2, err := client.decodeWith(c, g, encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	g2.BuiltCityAreaID = g.BuiltCityAreaID
+	g2.PlacedWorkers = g.PlacedWorkers
+	g2.From = g.From
+	g2.To = g.To
+	g2.ExpandedCity = g.ExpandedCity
+	if g.OtherPlayer != nil {
+		g2.OtherPlayer = g2.PlayerByID(g.OtherPlayer.ID())
+	}
+	return g2, nil
+}
+
+// decodeWith returns a game with a copy of the header of g and the provided encoded state.
+// The header is copied via its datastore properties.
+func (client *Client) decodeWith(c *gin.Context, g *Game, encoded []byte) (*Game, error) {
+	g2 := New(c, g.ID())
+	ps, err := datastore.SaveStruct(g.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	err = datastore.LoadStruct(g2.Header, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	s := newState()
+	err = codec.Decode(&s, encoded)
+	if err != nil {
+		return nil, err
+	}
+	g2.State = s
+
+	err = client.init(c, g2)
+	if err != nil {
+		return nil, err
+	}
+	return g2, nil
+}
@@ -20,9 +20,9 @@ func (g *Game) declinePhase(c *gin.Context) {
 	defer log.Debugf(msgExit)
 
 	g.Phase = Decline
-	m := make(declineMap, len(declineIDS()))
+	m := make(declineMap, len(g.declineIDS()))
 	if g.Turn == 2 || g.Turn == 4 {
-		for _, aid := range declineIDS() {
+		for _, aid := range g.declineIDS() {
 			a := g.Areas[aid]
 			workers := make(Workers, g.NumPlayers)
 			switch aid {
@@ -0,0 +1,172 @@
+package atf
+
+// stateDiff describes how the board changed between two versions of a game.
+// Only changed values are reported.
+type stateDiff struct {
+	Supply  Resources    `json:"supply,omitempty"`
+	Players []playerDiff `json:"players,omitempty"`
+	Areas   []areaDiff   `json:"areas,omitempty"`
+	Empires []empireDiff `json:"empires,omitempty"`
+}
+
+// playerDiff reports changes in the holdings of a player as deltas.
+type playerDiff struct {
+	PlayerID     int            `json:"playerId"`
+	Name         string         `json:"name"`
+	Score        int            `json:"score,omitempty"`
+	Resources    Resources      `json:"resources,omitempty"`
+	Worker       int            `json:"worker,omitempty"`
+	WorkerSupply int            `json:"workerSupply,omitempty"`
+	Army         int            `json:"army,omitempty"`
+	ArmySupply   int            `json:"armySupply,omitempty"`
+	City         int            `json:"city,omitempty"`
+	Expansion    int            `json:"expansion,omitempty"`
+	Workers      map[string]int `json:"workers,omitempty"`
+}
+
+// ownerChange reports the player ids of the previous and new owners.
+type ownerChange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+type areaDiff struct {
+	Area         string       `json:"area"`
+	Armies       int          `json:"armies,omitempty"`
+	ArmyOwner    *ownerChange `json:"armyOwner,omitempty"`
+	CityOwner    *ownerChange `json:"cityOwner,omitempty"`
+	CityExpanded *bool        `json:"cityExpanded,omitempty"`
+	Traded       []string     `json:"traded,omitempty"`
+}
+
+type empireDiff struct {
+	Turn      int          `json:"turn"`
+	Area      string       `json:"area"`
+	Owner     *ownerChange `json:"owner,omitempty"`
+	Rating    int          `json:"rating,omitempty"`
+	Equipment Resources    `json:"equipment,omitempty"`
+}
+
+// diffGames returns the changes to the board of g1 needed to obtain the board of g2.
+// Both games must have the same players and areas.
+func diffGames(g1, g2 *Game) *stateDiff {
+	d := new(stateDiff)
+	d.Supply = diffResources(g1.Resources, g2.Resources)
+
+	for _, p1 := range g1.Players() {
+		p2 := g2.PlayerByID(p1.ID())
+		if pd, changed := diffPlayers(p1, p2); changed {
+			d.Players = append(d.Players, pd)
+		}
+	}
+
+	for i, a1 := range g1.Areas {
+		if ad, changed := diffAreas(a1, g2.Areas[i]); changed {
+			d.Areas = append(d.Areas, ad)
+		}
+	}
+
+	for turn, es1 := range g1.EmpireTable {
+		for i, e1 := range es1 {
+			if ed, changed := diffEmpires(turn+1, e1, g2.EmpireTable[turn][i]); changed {
+				d.Empires = append(d.Empires, ed)
+			}
+		}
+	}
+	return d
+}
+
+func diffPlayers(p1, p2 *Player) (playerDiff, bool) {
+	g1, g2 := p1.Game(), p2.Game()
+	pd := playerDiff{
+		PlayerID:     p1.ID(),
+		Name:         g2.NameFor(p2),
+		Score:        p2.Score - p1.Score,
+		Resources:    diffResources(p1.Resources, p2.Resources),
+		Worker:       p2.Worker - p1.Worker,
+		WorkerSupply: p2.WorkerSupply - p1.WorkerSupply,
+		Army:         p2.Army - p1.Army,
+		ArmySupply:   p2.ArmySupply - p1.ArmySupply,
+		City:         p2.City - p1.City,
+		Expansion:    p2.Expansion - p1.Expansion,
+	}
+
+	for i, a1 := range g1.Areas {
+		if delta := p2.WorkersIn(g2.Areas[i]) - p1.WorkersIn(a1); delta != 0 {
+			if pd.Workers == nil {
+				pd.Workers = make(map[string]int)
+			}
+			pd.Workers[a1.Name()] = delta
+		}
+	}
+
+	changed := pd.Score != 0 || pd.Resources != nil || pd.Worker != 0 || pd.WorkerSupply != 0 ||
+		pd.Army != 0 || pd.ArmySupply != 0 || pd.City != 0 || pd.Expansion != 0 || pd.Workers != nil
+	return pd, changed
+}
+
+func diffAreas(a1, a2 *Area) (areaDiff, bool) {
+	ad := areaDiff{Area: a1.Name(), Armies: a2.Armies - a1.Armies}
+	changed := ad.Armies != 0
+
+	if a1.ArmyOwnerID != a2.ArmyOwnerID {
+		ad.ArmyOwner, changed = &ownerChange{From: a1.ArmyOwnerID, To: a2.ArmyOwnerID}, true
+	}
+
+	if a1.City.OwnerID != a2.City.OwnerID {
+		ad.CityOwner, changed = &ownerChange{From: a1.City.OwnerID, To: a2.City.OwnerID}, true
+	}
+
+	if a1.City.Expanded != a2.City.Expanded {
+		expanded := a2.City.Expanded
+		ad.CityExpanded, changed = &expanded, true
+	}
+
+	for r, status := range a2.Trade {
+		if r < len(a1.Trade) && status == traded && a1.Trade[r] != traded {
+			ad.Traded, changed = append(ad.Traded, Resource(r).LString()), true
+		}
+	}
+	return ad, changed
+}
+
+func diffEmpires(turn int, e1, e2 *Empire) (empireDiff, bool) {
+	ed := empireDiff{
+		Turn:      turn,
+		Area:      e1.AreaID.Name(),
+		Rating:    e2.Rating - e1.Rating,
+		Equipment: diffResources(e1.Equipment, e2.Equipment),
+	}
+	changed := ed.Rating != 0 || ed.Equipment != nil
+
+	if e1.OwnerID != e2.OwnerID {
+		ed.Owner, changed = &ownerChange{From: e1.OwnerID, To: e2.OwnerID}, true
+	}
+	return ed, changed
+}
+
+// diffResources returns rs2 - rs1, or nil if the resources are the same.
+func diffResources(rs1, rs2 Resources) Resources {
+	l := len(rs1)
+	if len(rs2) > l {
+		l = len(rs2)
+	}
+
+	var d Resources
+	for i := 0; i < l; i++ {
+		var v1, v2 int
+		if i < len(rs1) {
+			v1 = rs1[i]
+		}
+		if i < len(rs2) {
+			v2 = rs2[i]
+		}
+		if v1 != v2 {
+			if d == nil {
+				d = make(Resources, l)
+			}
+			d[i] = v2 - v1
+		}
+	}
+	return d
+}
@@ -0,0 +1,256 @@
+package atf
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/gob"
+	"html/template"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/SlothNinja/log"
+	"github.com/SlothNinja/restful"
+)
+
+func init() {
+	gob.Register(new(empireTableEntry))
+}
+
+const (
+	maxEmpireTableTries = 10000
+	// maxTurnArmiesSpread bounds the difference between the armies of a turn and the average armies of a turn.
+	maxTurnArmiesSpread = 5
+)
+
+type empireTier int
+
+const (
+	middleEmpire empireTier = iota
+	strongEmpire
+	weakEmpire
+)
+
+// empireTiers ranks the empires of pool by armies.  Of a pool dealt over turns turns,
+// the turns empires having the most armies are strong and the turns empires having the fewest are weak.
+// Empires having equal armies are ranked by their order in pool.
+func empireTiers(pool Empires, turns int) map[*Empire]empireTier {
+	ranked := append(Empires(nil), pool...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Armies > ranked[j].Armies })
+
+	tiers := make(map[*Empire]empireTier, len(ranked))
+	for i, e := range ranked {
+		switch {
+		case i < turns:
+			tiers[e] = strongEmpire
+		case i >= len(ranked)-turns:
+			tiers[e] = weakEmpire
+		default:
+			tiers[e] = middleEmpire
+		}
+	}
+	return tiers
+}
+
+// empireTableConstraint reports whether a generated empire table is acceptable, given the tiers of its empires.
+type empireTableConstraint struct {
+	Name  string
+	Check func(EmpireTable, map[*Empire]empireTier) bool
+}
+
+var empireTableConstraints = []empireTableConstraint{
+	{Name: "each turn has one strong empire", Check: func(t EmpireTable, tiers map[*Empire]empireTier) bool {
+		return t.everyTurn(func(es Empires) bool { return es.count(inTier(tiers, strongEmpire)) == 1 })
+	}},
+	{Name: "each turn has one weak empire", Check: func(t EmpireTable, tiers map[*Empire]empireTier) bool {
+		return t.everyTurn(func(es Empires) bool { return es.count(inTier(tiers, weakEmpire)) == 1 })
+	}},
+	{Name: "no area appears twice in a turn", Check: func(t EmpireTable, _ map[*Empire]empireTier) bool {
+		return t.everyTurn(func(es Empires) bool {
+			seen := make(map[AreaID]bool, len(es))
+			for _, e := range es {
+				if seen[e.AreaID] {
+					return false
+				}
+				seen[e.AreaID] = true
+			}
+			return true
+		})
+	}},
+	{Name: "Sumer empires are not in consecutive turns", Check: func(t EmpireTable, _ map[*Empire]empireTier) bool {
+		isSumer := func(e *Empire) bool { return e.AreaID == Sumer }
+		for turn := 1; turn < len(t); turn++ {
+			if t[turn-1].count(isSumer) > 0 && t[turn].count(isSumer) > 0 {
+				return false
+			}
+		}
+		return true
+	}},
+	{Name: "turns have similar armies", Check: func(t EmpireTable, _ map[*Empire]empireTier) bool {
+		total := 0
+		for _, es := range t {
+			total += es.armies()
+		}
+		avg := total / len(t)
+		return t.everyTurn(func(es Empires) bool {
+			diff := es.armies() - avg
+			return diff <= maxTurnArmiesSpread && diff >= -maxTurnArmiesSpread
+		})
+	}},
+}
+
+func inTier(tiers map[*Empire]empireTier, tier empireTier) func(*Empire) bool {
+	return func(e *Empire) bool { return tiers[e] == tier }
+}
+
+func (t EmpireTable) everyTurn(f func(Empires) bool) bool {
+	for _, es := range t {
+		if !f(es) {
+			return false
+		}
+	}
+	return true
+}
+
+func (es Empires) count(f func(*Empire) bool) int {
+	cnt := 0
+	for _, e := range es {
+		if f(e) {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+func (es Empires) armies() int {
+	armies := 0
+	for _, e := range es {
+		armies += e.Armies
+	}
+	return armies
+}
+
+func (t EmpireTable) valid(tiers map[*Empire]empireTier) bool {
+	for _, c := range empireTableConstraints {
+		if !c.Check(t, tiers) {
+			return false
+		}
+	}
+	return true
+}
+
+// randomEmpireTable deals the empires of the default table into a new schedule satisfying empireTableConstraints.
+// The same seed always provides the same table.  ok is false, and the default table returned,
+// if no acceptable table is found.
+func randomEmpireTable(seed int64) (t EmpireTable, ok bool) {
+	r := rand.New(rand.NewSource(seed))
+	defaults := defaultEmpireTable()
+
+	var pool Empires
+	for _, es := range defaults {
+		pool = append(pool, es...)
+	}
+	tiers := empireTiers(pool, len(defaults))
+
+	for try := 0; try < maxEmpireTableTries; try++ {
+		r.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+		t := make(EmpireTable, len(defaults))
+		for i, e := range pool {
+			turn := i % len(t)
+			t[turn] = append(t[turn], e)
+		}
+
+		if t.valid(tiers) {
+			return t.copy(), true
+		}
+	}
+	return defaults, false
+}
+
+// copy returns a table of new, unowned empires having the areas and armies of the empires of t.
+func (t EmpireTable) copy() EmpireTable {
+	t2 := make(EmpireTable, len(t))
+	for turn, es := range t {
+		for _, e := range es {
+			t2[turn] = append(t2[turn], &Empire{
+				AreaID:    e.AreaID,
+				Armies:    e.Armies,
+				OwnerID:   NoPlayerID,
+				Equipment: Resources{},
+			})
+		}
+	}
+	return t2
+}
+
+// newSeed returns a non-zero seed for randomizing the setup of a game.
+func newSeed() int64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err == nil {
+		if seed := int64(binary.LittleEndian.Uint64(b[:]) >> 1); seed != 0 {
+			return seed
+		}
+	}
+	return time.Now().UnixNano()
+}
+
+// setupEmpireTable sets the empire table of the game per its variant.
+// EmpireSeed is set only if the empires were randomized.
+func (g *Game) setupEmpireTable() {
+	g.EmpireTable = defaultEmpireTable()
+	if g.variant()["empires"] == "random" {
+		seed := newSeed()
+		if t, ok := randomEmpireTable(seed); ok {
+			g.EmpireSeed, g.EmpireTable = seed, t
+		} else {
+			log.Warningf("no acceptable empire table found for seed %d; using the default table", seed)
+		}
+	}
+	g.initEmpireTable()
+}
+
+type empireTableEntry struct {
+	*Entry
+	Seed  int64
+	Table [][]empireTableRow
+}
+
+type empireTableRow struct {
+	AreaID AreaID
+	Armies int
+}
+
+func (g *Game) newEmpireTableEntry() {
+	e := &empireTableEntry{
+		Entry: g.newEntry(),
+		Seed:  g.EmpireSeed,
+		Table: make([][]empireTableRow, len(g.EmpireTable)),
+	}
+	for i, es := range g.EmpireTable {
+		for _, emp := range es {
+			e.Table[i] = append(e.Table[i], empireTableRow{emp.AreaID, emp.Armies})
+		}
+	}
+	g.Log = append(g.Log, e)
+}
+
+func (e *empireTableEntry) HTML() template.HTML {
+	s := restful.HTML("<div>The empires were randomized using seed %d.</div><div>&nbsp;</div>", e.Seed)
+	if e.Seed == 0 {
+		s = restful.HTML("<div>No balanced random schedule of empires was found, so the standard empires are used.</div><div>&nbsp;</div>")
+	}
+	s += restful.HTML("<table class='strippedDataTable'><thead><tr><th>Turn</th><th>Empires</th></tr></thead><tbody>")
+	for i, rows := range e.Table {
+		s += restful.HTML("<tr><td>%d</td><td>", i+1)
+		for j, row := range rows {
+			if j > 0 {
+				s += restful.HTML(", ")
+			}
+			s += restful.HTML("%s (%d armies)", row.AreaID, row.Armies)
+		}
+		s += restful.HTML("</td></tr>")
+	}
+	s += restful.HTML("</tbody></table>")
+	return s
+}
@@ -0,0 +1,133 @@
+package atf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEmpireTiers(t *testing.T) {
+	var pool Empires
+	for _, es := range defaultEmpireTable() {
+		pool = append(pool, es...)
+	}
+	tiers := empireTiers(pool, 5)
+
+	counts := make(map[empireTier]int)
+	for _, e := range pool {
+		counts[tiers[e]]++
+	}
+	if want := map[empireTier]int{strongEmpire: 5, middleEmpire: 5, weakEmpire: 5}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("tier counts = %v; want %v", counts, want)
+	}
+
+	for _, e := range pool {
+		switch {
+		case e.Armies == 12 && tiers[e] != strongEmpire:
+			t.Errorf("%s (%d armies) is not strong", e.AreaID, e.Armies)
+		case e.Armies <= 5 && tiers[e] != weakEmpire:
+			t.Errorf("%s (%d armies) is not weak", e.AreaID, e.Armies)
+		}
+	}
+}
+
+func TestEmpireTableConstraints(t *testing.T) {
+	empire := func(aid AreaID, armies int) *Empire {
+		return &Empire{AreaID: aid, Armies: armies, OwnerID: NoPlayerID}
+	}
+
+	var (
+		akkad, amorites       = empire(Akkad, 10), empire(Amorites, 10)
+		guti, isin, larsa     = empire(Guti, 8), empire(Isin, 5), empire(Larsa, 5)
+		sumer1, sumer2, egypt = empire(Sumer, 3), empire(Sumer, 3), empire(Egypt, 5)
+	)
+	tiers := map[*Empire]empireTier{
+		akkad: strongEmpire, amorites: strongEmpire,
+		guti: middleEmpire,
+		isin: weakEmpire, larsa: weakEmpire, sumer1: weakEmpire, sumer2: weakEmpire, egypt: weakEmpire,
+	}
+
+	tests := []struct {
+		name  string
+		table EmpireTable
+		// fails lists the constraints the table fails
+		fails []string
+	}{
+		{"acceptable", EmpireTable{{akkad, guti, isin}, {amorites, guti, larsa}}, nil},
+		{
+			"two strong empires",
+			EmpireTable{{akkad, amorites, isin}, {guti, empire(Elam, 8), larsa}},
+			[]string{"each turn has one strong empire"},
+		},
+		{
+			"two weak empires",
+			EmpireTable{{akkad, isin, larsa}, {amorites, guti, egypt}},
+			[]string{"each turn has one weak empire"},
+		},
+		{
+			"area twice in a turn",
+			EmpireTable{{akkad, guti, isin}, {amorites, larsa, empire(Larsa, 8)}},
+			[]string{"no area appears twice in a turn"},
+		},
+		{
+			"Sumer in consecutive turns",
+			EmpireTable{{akkad, guti, sumer1}, {amorites, guti, sumer2}},
+			[]string{"Sumer empires are not in consecutive turns"},
+		},
+		{
+			"uneven armies",
+			EmpireTable{{akkad, empire(Assyria, 15), isin}, {amorites, empire(Elam, 1), larsa}},
+			[]string{"turns have similar armies"},
+		},
+	}
+
+	for _, test := range tests {
+		var fails []string
+		for _, c := range empireTableConstraints {
+			if !c.Check(test.table, tiers) {
+				fails = append(fails, c.Name)
+			}
+		}
+		if !reflect.DeepEqual(fails, test.fails) {
+			t.Errorf("%s: fails %v; want %v", test.name, fails, test.fails)
+		}
+	}
+}
+
+func TestRandomEmpireTable(t *testing.T) {
+	// the tiers of the default empires, identified by area and armies
+	type empireKey struct {
+		AreaID AreaID
+		Armies int
+	}
+	var defaults Empires
+	for _, es := range defaultEmpireTable() {
+		defaults = append(defaults, es...)
+	}
+	keyTiers := make(map[empireKey]empireTier)
+	for e, tier := range empireTiers(defaults, 5) {
+		keyTiers[empireKey{e.AreaID, e.Armies}] = tier
+	}
+
+	for seed := int64(1); seed <= 50; seed++ {
+		table, ok := randomEmpireTable(seed)
+		if !ok {
+			t.Errorf("seed %d: no acceptable table found", seed)
+			continue
+		}
+
+		tiers := make(map[*Empire]empireTier)
+		for _, es := range table {
+			for _, e := range es {
+				tiers[e] = keyTiers[empireKey{e.AreaID, e.Armies}]
+			}
+		}
+		if !table.valid(tiers) {
+			t.Errorf("seed %d: table violates the constraints", seed)
+		}
+
+		again, _ := randomEmpireTable(seed)
+		if !reflect.DeepEqual(table, again) {
+			t.Errorf("seed %d: tables differ for the same seed", seed)
+		}
+	}
+}
@@ -27,6 +27,8 @@ func (client *Client) endGame(c *gin.Context, g *Game) ([]*contest.Contest, erro
 	if err != nil {
 		return nil, err
 	}
+	g.newScoreBreakdownEntry()
+	g.newTieBreakEntry(g.placePlayers())
 	g.SetWinners(places[0])
 	g.newEndGameEntry()
 	return contest.GenContests(c, places), nil
@@ -58,6 +60,12 @@ func (g *Game) SetWinners(rmap contest.ResultsMap) {
 	}
 
 	g.newAnnounceWinnersEntry()
+
+	scores := make([]int, g.NumPlayers)
+	for _, p := range g.Players() {
+		scores[p.ID()] = p.Score
+	}
+	g.emit(nil, &GameEnded{WinnerIDs: g.WinnerIDS, Scores: scores})
 }
 
 func (g *Game) SendEndGameNotifications(c *gin.Context) error {
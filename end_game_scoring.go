@@ -20,17 +20,22 @@ func (client *Client) endGameScoring(c *gin.Context, g *Game) ([]*contest.Contes
 	defer client.Log.Debugf(msgExit)
 
 	g.Phase = EndOfTurn
-	m := make(endGameScoringMap, len(scoringIDS()))
-	for _, aid := range scoringIDS() {
+	m := make(endGameScoringMap, len(g.scoringIDS()))
+	for _, aid := range g.scoringIDS() {
 		a := g.Areas[aid]
 		for _, p := range g.Players() {
 			if p.hasMostWorkersIn(a) {
 				m[a.ID] = p.ID()
-				p.Score += a.Score()
+				p.addScore(workerMajorityScore, a.ID, a.Score())
 			}
 		}
 	}
 	g.newEndGameScoringEntry(m)
+	majorities := make(map[string]int, len(m))
+	for aid, pid := range m {
+		majorities[aid.Name()] = pid
+	}
+	g.emit(nil, &EndGameScored{Majorities: majorities})
 	return client.endGame(c, g)
 }
 
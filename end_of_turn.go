@@ -10,10 +10,7 @@ func (g *Game) endOfTurn(c *gin.Context) {
 	defer log.Debugf(msgExit)
 
 	g.Phase = EndOfTurn
-	g.returnArmies(c)
-	g.returnWorkers(c)
-	g.resetPassboxes(c)
-	g.resetArmyBoxes(c)
+	g.runPipeline(c, endOfTurnPipeline)
 }
 
 func (g *Game) returnArmies(c *gin.Context) {
@@ -16,13 +16,8 @@ func init() {
 	gob.Register(new(equipArmyEntry))
 }
 
-func (g *Game) equipArmy(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
-	var equipArmyResources Resources
-	if equipArmyResources, err = g.validateEquipArmy(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
+func (g *Game) equipArmy(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
+	equipArmyResources := args.Resources
 	cp := g.CurrentPlayer()
 	for resource, count := range equipArmyResources {
 		cp.Resources[resource] -= count
@@ -38,64 +33,59 @@ func (g *Game) equipArmy(c *gin.Context, cu *user.User) (tmpl string, act game.A
 	e := cp.newEquipArmyEntry(equipArmyResources)
 	restful.AddNoticef(c, string(e.HTML()))
 
-	tmpl, act = "atf/equip_army_update", game.Cache
+	act = game.Cache
 	return
 }
 
 func (g *Game) updateEmpireRatings(empire *Empire) {
-	empires := g.StartedEmpires()
-	l := len(empires)
-	cp := g.CurrentPlayer()
-	switch l {
+	rating, rival, rivalRating := g.rateEmpire(g.CurrentPlayer(), empire.Value())
+	empire.Rating = rating
+	if rival != nil {
+		rival.Rating = rivalRating
+	}
+}
+
+// rateEmpire returns the rating of an empire of p equipped with the provided value,
+// together with the rival empire it outranks and the rival's resulting rating, if any.
+func (g *Game) rateEmpire(p *Player, value int) (rating int, rival *Empire, rivalRating int) {
+	var rivals Empires
+	for _, emp := range g.StartedEmpires() {
+		if !emp.Owner().Equal(p) {
+			rivals = append(rivals, emp)
+		}
+	}
+
+	switch len(rivals) {
+	case 0:
+		return 4, nil, 0
 	case 1:
-		empire.Rating = 4
-	case 2:
-		for _, emp := range empires {
-			if !emp.Owner().Equal(cp) {
-				if empire.Value() > emp.Value() {
-					emp.Rating, empire.Rating = 2, 4
-					return
-
-				} else {
-					empire.Rating = 2
-					return
-				}
-			}
+		if value > rivals[0].Value() {
+			return 4, rivals[0], 2
 		}
-	case 3:
-		for _, emp := range empires {
-			if !emp.Owner().Equal(cp) {
-				if empire.Value() > emp.Value() {
-					empire.Rating, emp.Rating = emp.Rating, emp.Rating-1
-					return
-				}
+		return 2, nil, 0
+	default:
+		for _, emp := range rivals {
+			if value > emp.Value() {
+				return emp.Rating, emp, emp.Rating - 1
 			}
 		}
-		empire.Rating = 1
+		return 1, nil, 0
 	}
 }
 
-func (g *Game) validateEquipArmy(c *gin.Context, cu *user.User) (rs Resources, err error) {
-	if err = g.validatePlayerAction(cu); err != nil {
+func (g *Game) validateEquipArmy(cu *user.User, args *actionArgs) (err error) {
+	if err = g.validateMultiAction(cu, "equip-army"); err != nil {
 		return
 	}
 
 	cp := g.CurrentPlayer()
-	if cp.PerformedAction {
-		err = sn.NewVError("You have already performed an action.")
-		return
-	}
 
 	if cp.empire() == nil {
 		err = sn.NewVError("You do not have an army to equip.")
 		return
 	}
 
-	if rs, err = getResourcesFrom(c); err != nil {
-		return
-	}
-
-	for i, cnt := range rs {
+	for i, cnt := range args.Resources {
 		r := Resource(i)
 		if cnt > cp.Resources[r] {
 			err = sn.NewVError("You do not have %d %s.", cnt, r)
@@ -117,7 +107,6 @@ func (p *Player) newEquipArmyEntry(resources Resources) *equipArmyEntry {
 		Entry:              p.newEntry(),
 		EquipArmyResources: resources,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -0,0 +1,85 @@
+package atf
+
+import "testing"
+
+// rivalEmpire is a started empire of a rival of the first player.
+type rivalEmpire struct {
+	rating    int
+	equipment Resources
+}
+
+// newRatingGame returns a game in which the first player started the first empire of the turn,
+// and a rival started each of the next empires.
+func newRatingGame(rivals []rivalEmpire) (*Game, *Empire, Empires) {
+	g := newTestGame()
+	g.setupEmpireTable()
+	g.Turn = 1
+	g.setCurrentPlayers(g.Players()[0])
+
+	empires := g.CurrentEmpires()
+	empires[0].OwnerID = g.Players()[0].ID()
+	for i, r := range rivals {
+		emp := empires[i+1]
+		emp.OwnerID = g.Players()[i+1].ID()
+		emp.Rating, emp.Equipment = r.rating, r.equipment
+	}
+	return g, empires[0], empires[1 : len(rivals)+1]
+}
+
+func TestRateEmpire(t *testing.T) {
+	var (
+		// value 6
+		strong = rivalEmpire{4, Resources{Wood: 1, Gold: 1}}
+		// value 3
+		weak = rivalEmpire{2, Resources{Tool: 1}}
+	)
+
+	// the wants are those of updateEmpireRatings before rateEmpire was extracted from it
+	tests := []struct {
+		name   string
+		rivals []rivalEmpire
+		value  int
+		rating int
+		// outranked is the index in rivals of the rival outranked, or -1
+		outranked   int
+		rivalRating int
+	}{
+		{"no rivals", nil, 0, 4, -1, 0},
+		{"no rivals equipped", nil, 5, 4, -1, 0},
+		{"one rival outranked", []rivalEmpire{strong}, 7, 4, 0, 2},
+		{"one rival tied", []rivalEmpire{strong}, 6, 2, -1, 0},
+		{"one rival outranking", []rivalEmpire{strong}, 2, 2, -1, 0},
+		{"two rivals, both outranked", []rivalEmpire{strong, weak}, 7, 4, 0, 3},
+		{"two rivals, weaker outranked", []rivalEmpire{strong, weak}, 4, 2, 1, 1},
+		{"two rivals, weaker tied", []rivalEmpire{strong, weak}, 3, 1, -1, 0},
+		{"two rivals, listed weaker first", []rivalEmpire{weak, strong}, 4, 2, 0, 1},
+	}
+
+	for _, test := range tests {
+		g, _, rivals := newRatingGame(test.rivals)
+
+		rating, rival, rivalRating := g.rateEmpire(g.Players()[0], test.value)
+		if rating != test.rating {
+			t.Errorf("%s: rating = %d; want %d", test.name, rating, test.rating)
+		}
+
+		switch {
+		case test.outranked == -1 && rival != nil:
+			t.Errorf("%s: outranked %s; want none", test.name, rival.AreaID)
+		case test.outranked != -1 && rival != rivals[test.outranked]:
+			t.Errorf("%s: outranked %v; want %s", test.name, rival, rivals[test.outranked].AreaID)
+		case rivalRating != test.rivalRating:
+			t.Errorf("%s: rival rating = %d; want %d", test.name, rivalRating, test.rivalRating)
+		}
+	}
+}
+
+func TestUpdateEmpireRatings(t *testing.T) {
+	g, empire, rivals := newRatingGame([]rivalEmpire{{4, Resources{Gold: 1}}, {2, Resources{Grain: 1}}})
+	empire.Equipment = Resources{Tool: 1}
+
+	g.updateEmpireRatings(empire)
+	if got := []int{empire.Rating, rivals[0].Rating, rivals[1].Rating}; got[0] != 2 || got[1] != 4 || got[2] != 1 {
+		t.Errorf("ratings = %v; want [2 4 1]", got)
+	}
+}
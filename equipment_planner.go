@@ -0,0 +1,168 @@
+package atf
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// equipmentTier is the cheapest equipment from a player's resources that achieves a rating.
+type equipmentTier struct {
+	Rating       int            `json:"rating"`
+	Needed       int            `json:"needed"`
+	Affordable   bool           `json:"affordable"`
+	Equipment    map[string]int `json:"equipment,omitempty"`
+	Value        int            `json:"value"`
+	ArmyValue    int            `json:"armyValue"`
+	ArmiesBefore int            `json:"armiesBefore"`
+	ArmiesAfter  int            `json:"armiesAfter"`
+	OutranksID   int            `json:"outranksId,omitempty"`
+}
+
+// equipmentTiers returns, for each rating an empire of p can achieve against the started empires,
+// the least value of equipment needed and the cheapest equipment p can provide for it.
+func (p *Player) equipmentTiers() []*equipmentTier {
+	g := p.Game()
+
+	needed := []int{0}
+	for _, emp := range g.StartedEmpires() {
+		if !emp.Owner().Equal(p) {
+			needed = append(needed, emp.Value()+1)
+		}
+	}
+
+	var (
+		tiers []*equipmentTier
+		seen  = make(map[int]bool)
+	)
+	for _, v := range needed {
+		rating, rival, _ := g.rateEmpire(p, v)
+		if seen[rating] {
+			continue
+		}
+		seen[rating] = true
+
+		t := &equipmentTier{
+			Rating:       rating,
+			Needed:       v,
+			ArmiesBefore: p.armiesFor(p.Resources),
+		}
+		if rival != nil {
+			t.OutranksID = rival.OwnerID
+		}
+
+		if equipment := p.cheapestEquipment(v); equipment != nil {
+			t.Affordable = true
+			t.Equipment = make(map[string]int)
+			for i, cnt := range equipment {
+				if cnt > 0 {
+					t.Equipment[Resource(i).LString()] = cnt
+				}
+			}
+			t.Value = equipment.Value()
+			t.ArmyValue = equipment.ArmyValue()
+
+			remaining := make(Resources, len(p.Resources))
+			for i, cnt := range p.Resources {
+				remaining[i] = cnt - equipment[i]
+			}
+			t.ArmiesAfter = p.armiesFor(remaining)
+		}
+		tiers = append(tiers, t)
+	}
+	return tiers
+}
+
+// armiesFor returns the number of armies p could buy with the provided resources.
+func (p *Player) armiesFor(rs Resources) int {
+	if v := rs.ArmyValue(); v < p.ArmySupply {
+		return v
+	}
+	return p.ArmySupply
+}
+
+// cheapestEquipment returns the resources of p having the least value no less than needed.
+// Among equally valued equipment, the one using the least army value is returned,
+// so as to leave the most resources for buying armies.
+// Returns nil, if p lacks the resources.
+func (p *Player) cheapestEquipment(needed int) Resources {
+	total := p.Resources.Value()
+	if total < needed {
+		return nil
+	}
+
+	// best[v] is the equipment of value v using the least army value
+	best := make([]Resources, total+1)
+	best[0] = make(Resources, len(p.Resources))
+	for i, cnt := range p.Resources {
+		r := Resource(i)
+		for k := 0; k < cnt; k++ {
+			for v := total; v >= r.Value(); v-- {
+				prev := best[v-r.Value()]
+				if prev == nil {
+					continue
+				}
+				if best[v] != nil && best[v].ArmyValue() <= prev.ArmyValue()+resourceArmyValueMap[r] {
+					continue
+				}
+				rs := append(Resources(nil), prev...)
+				rs[r] += 1
+				best[v] = rs
+			}
+		}
+	}
+
+	for v := needed; v <= total; v++ {
+		if best[v] != nil {
+			return best[v]
+		}
+	}
+	return nil
+}
+
+// equipmentPlanner provides the cheapest equipment achieving each rating against the started empires,
+// and the armies that could still be bought after paying for it.
+func (client *Client) equipmentPlanner(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		p, err := client.requestedPlayer(c, g)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		rivals := make([]gin.H, 0)
+		for _, emp := range g.StartedEmpires() {
+			if !emp.Owner().Equal(p) {
+				rivals = append(rivals, gin.H{
+					"area":    emp.AreaID.String(),
+					"ownerId": emp.OwnerID,
+					"rating":  emp.Rating,
+					"value":   emp.Value(),
+				})
+			}
+		}
+
+		h := gin.H{
+			"playerId": p.ID(),
+			"rivals":   rivals,
+			"tiers":    p.equipmentTiers(),
+		}
+		if emp := p.empire(); emp != nil {
+			h["empire"] = gin.H{
+				"area":   emp.AreaID.String(),
+				"rating": emp.Rating,
+				"value":  emp.Value(),
+			}
+		}
+		c.JSON(http.StatusOK, h)
+	}
+}
@@ -0,0 +1,79 @@
+package atf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheapestEquipment(t *testing.T) {
+	// worth 1, 1, 2, 3 and 4
+	resources := Resources{Grain: 2, Wood: 1, Tool: 1, Gold: 1, Lapis: 0}
+
+	tests := []struct {
+		needed int
+		want   Resources
+	}{
+		{0, Resources{0, 0, 0, 0, 0, 0, 0, 0}},
+		// grain and wood leave the tool for buying armies
+		{3, Resources{Grain: 1, Wood: 1, Lapis: 0}},
+		// gold costs no army value
+		{4, Resources{Gold: 1, Lapis: 0}},
+		{5, Resources{Grain: 1, Gold: 1, Lapis: 0}},
+		{11, resources},
+		{12, nil},
+	}
+
+	for _, test := range tests {
+		g := newTestGame()
+		p := g.Players()[0]
+		p.Resources = append(Resources(nil), resources...)
+
+		if got := p.cheapestEquipment(test.needed); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("needed %d: equipment = %v; want %v", test.needed, got, test.want)
+		}
+	}
+}
+
+func TestEquipmentTiers(t *testing.T) {
+	tests := []struct {
+		name  string
+		rival rivalEmpire
+		want  []equipmentTier
+	}{
+		{
+			"affordable",
+			rivalEmpire{4, Resources{Gold: 1}},
+			[]equipmentTier{
+				{Rating: 2, Needed: 0, Affordable: true, Equipment: map[string]int{}, ArmiesBefore: 5, ArmiesAfter: 5},
+				{
+					Rating: 4, Needed: 5, Affordable: true, Equipment: map[string]int{"grain": 1, "gold": 1},
+					Value: 5, ArmyValue: 1, ArmiesBefore: 5, ArmiesAfter: 4, OutranksID: 1,
+				},
+			},
+		},
+		{
+			"out of reach",
+			rivalEmpire{4, Resources{Lapis: 2, Gold: 1}},
+			[]equipmentTier{
+				{Rating: 2, Needed: 0, Affordable: true, Equipment: map[string]int{}, ArmiesBefore: 5, ArmiesAfter: 5},
+				{Rating: 4, Needed: 15, ArmiesBefore: 5, OutranksID: 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		g, _, _ := newRatingGame([]rivalEmpire{test.rival})
+		p := g.Players()[0]
+		p.Resources = Resources{Grain: 2, Wood: 1, Tool: 1, Gold: 1, Lapis: 0}
+
+		tiers := p.equipmentTiers()
+		if len(tiers) != len(test.want) {
+			t.Fatalf("%s: %d tiers; want %d", test.name, len(tiers), len(test.want))
+		}
+		for i, tier := range tiers {
+			if !reflect.DeepEqual(*tier, test.want[i]) {
+				t.Errorf("%s: tier %d = %+v; want %+v", test.name, i, *tier, test.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package atf
+
+import (
+	"encoding/gob"
+	"time"
+
+	"github.com/SlothNinja/game"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gob.Register(new(TurnStarted))
+	gob.Register(new(CityBuilt))
+	gob.Register(new(CityAbandoned))
+	gob.Register(new(CityDestroyed))
+	gob.Register(new(CityExpanded))
+	gob.Register(new(EmpireStarted))
+	gob.Register(new(ArmyReinforced))
+	gob.Register(new(AreaInvaded))
+	gob.Register(new(EmpiresScored))
+	gob.Register(new(TurnOrderDecided))
+	gob.Register(new(EndGameScored))
+	gob.Register(new(GameEnded))
+}
+
+// Event is a domain event emitted by the game engine.
+// Events are queued in the state of the game, so they survive caching and copying,
+// and are published to subscribers once the game is saved,
+// so subscribers never observe actions that are later undone.
+type Event interface {
+	EventName() string
+	header() *EventHeader
+}
+
+// EventHeader holds the values common to all events.
+type EventHeader struct {
+	GameID    int64      `json:"gameId"`
+	Turn      int        `json:"turn"`
+	Phase     game.Phase `json:"phase"`
+	PlayerID  int        `json:"playerId"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+func (h *EventHeader) header() *EventHeader {
+	return h
+}
+
+// Subscriber receives the events of saved games.
+type Subscriber interface {
+	Notify(c *gin.Context, e Event)
+}
+
+// SubscriberFunc adapts a function to the Subscriber interface.
+type SubscriberFunc func(*gin.Context, Event)
+
+func (f SubscriberFunc) Notify(c *gin.Context, e Event) {
+	f(c, e)
+}
+
+// Subscribe registers s to receive events.
+// Subscribers should be registered before the client begins serving requests.
+func (client *Client) Subscribe(s Subscriber) *Client {
+	client.subscribers = append(client.subscribers, s)
+	return client
+}
+
+// emit queues e for publication, attributing it to p, if any.
+func (g *Game) emit(p *Player, e Event) {
+	h := e.header()
+	h.GameID, h.Turn, h.Phase, h.PlayerID, h.CreatedAt = g.ID(), g.Turn, g.Phase, NoPlayerID, time.Now()
+	if p != nil {
+		h.PlayerID = p.ID()
+	}
+	g.Events = append(g.Events, e)
+}
+
+// publish delivers the events es of g to subscribers.
+func (client *Client) publish(c *gin.Context, g *Game, es []Event) {
+	for _, e := range es {
+		client.Log.Debugf("publishing %s event for game %d", e.EventName(), g.ID())
+		for _, s := range client.subscribers {
+			s.Notify(c, e)
+		}
+	}
+}
+
+// TurnStarted is emitted at the start of each turn.
+type TurnStarted struct {
+	EventHeader
+}
+
+func (e *TurnStarted) EventName() string { return "TurnStarted" }
+
+// CityBuilt is emitted when a player builds a city.
+type CityBuilt struct {
+	EventHeader
+	Area string `json:"area"`
+}
+
+func (e *CityBuilt) EventName() string { return "CityBuilt" }
+
+// CityAbandoned is emitted when a player abandons a city to build another.
+type CityAbandoned struct {
+	EventHeader
+	Area string `json:"area"`
+}
+
+func (e *CityAbandoned) EventName() string { return "CityAbandoned" }
+
+// CityDestroyed is emitted when a player destroys the city of another player.
+type CityDestroyed struct {
+	EventHeader
+	Area     string `json:"area"`
+	OwnerID  int    `json:"ownerId"`
+	Expanded bool   `json:"expanded"`
+}
+
+func (e *CityDestroyed) EventName() string { return "CityDestroyed" }
+
+// CityExpanded is emitted when a player expands a city.
+type CityExpanded struct {
+	EventHeader
+	Area   string `json:"area"`
+	Points int    `json:"points"`
+}
+
+func (e *CityExpanded) EventName() string { return "CityExpanded" }
+
+// EmpireStarted is emitted when a player starts an empire.
+type EmpireStarted struct {
+	EventHeader
+	Area   string `json:"area"`
+	Armies int    `json:"armies"`
+}
+
+func (e *EmpireStarted) EventName() string { return "EmpireStarted" }
+
+// ArmyReinforced is emitted when a player adds a second army to an area.
+type ArmyReinforced struct {
+	EventHeader
+	Area string `json:"area"`
+}
+
+func (e *ArmyReinforced) EventName() string { return "ArmyReinforced" }
+
+// AreaInvaded is emitted for each attempt to invade an area.
+// Invasions of unoccupied areas have no defender or roll and always succeed.
+type AreaInvaded struct {
+	EventHeader
+	Area       string `json:"area"`
+	DefenderID int    `json:"defenderId"`
+	Roll       int    `json:"roll,omitempty"`
+	Needed     int    `json:"needed,omitempty"`
+	Success    bool   `json:"success"`
+}
+
+func (e *AreaInvaded) EventName() string { return "AreaInvaded" }
+
+// EmpiresScored is emitted when empires are scored at the end of the actions phase.
+type EmpiresScored struct {
+	EventHeader
+	Scores []int `json:"scores"`
+}
+
+func (e *EmpiresScored) EventName() string { return "EmpiresScored" }
+
+// TurnOrderDecided is emitted when the turn order bids are resolved.
+type TurnOrderDecided struct {
+	EventHeader
+	Previous []int `json:"previous"`
+	Order    []int `json:"order"`
+}
+
+func (e *TurnOrderDecided) EventName() string { return "TurnOrderDecided" }
+
+// EndGameScored is emitted when worker majorities are scored at the end of the game.
+// Majorities maps the name of each scoring area to the player id of the player with the majority.
+type EndGameScored struct {
+	EventHeader
+	Majorities map[string]int `json:"majorities"`
+}
+
+func (e *EndGameScored) EventName() string { return "EndGameScored" }
+
+// GameEnded is emitted when the winners of a game are announced.
+type GameEnded struct {
+	EventHeader
+	WinnerIDs []int `json:"winnerIds"`
+	Scores    []int `json:"scores"`
+}
+
+func (e *GameEnded) EventName() string { return "GameEnded" }
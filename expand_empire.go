@@ -20,20 +20,11 @@ func init() {
 	gob.Register(new(unsuccessfulInvasionEntry))
 }
 
-func (g *Game) reinforceArmy(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) reinforceArmy(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var (
-		a      *Area
-		armies int
-	)
-
-	if a, armies, err = g.validateReinforceArmy(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
+	a, armies := g.SelectedArea(), 1+g.expansionCost()
 	cp := g.CurrentPlayer()
 	g.MultiAction = expandEmpireMA
 	a.Armies += 1
@@ -42,27 +33,30 @@ func (g *Game) reinforceArmy(c *gin.Context, cu *user.User) (tmpl string, act ga
 		cp.ArmySupply += 1
 	}
 	cp.PerformedAction = true
+	g.emit(cp, &ArmyReinforced{Area: a.Name()})
 
 	// Log Reinforcement
 	e := cp.newReinforceArmy(a, armies)
 	restful.AddNoticef(c, string(e.HTML()))
 
-	tmpl, act = "atf/reinforce_army_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateReinforceArmy(c *gin.Context, cu *user.User) (a *Area, armies int, err error) {
+func (g *Game) validateReinforceArmy(cu *user.User, args *actionArgs) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var cp *Player
+	var (
+		a      *Area
+		cp     *Player
+		armies int
+	)
 
-	switch a, cp, armies, err = g.SelectedArea(), g.CurrentPlayer(), 1+g.expansionCost(), g.validatePlayerAction(cu); {
+	switch a, cp, armies, err = g.SelectedArea(), g.CurrentPlayer(), 1+g.expansionCost(), g.validateMultiAction(cu, "reinforce-army"); {
 	case err != nil:
 	case a == nil:
 		err = sn.NewVError("No area selected.")
-	case cp.PerformedAction && g.MultiAction != expandEmpireMA:
-		err = sn.NewVError("You have already performed an action.")
 	case !cp.hasArmyIn(a):
 		err = sn.NewVError("You do not have an army in %s.", a.Name())
 	case cp.ArmiesIn(a) == 2:
@@ -86,7 +80,6 @@ func (p *Player) newReinforceArmy(a *Area, armies int) *reinforceArmyEntry {
 		AreaName: a.Name(),
 		Armies:   armies,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -98,20 +91,11 @@ func (e *reinforceArmyEntry) HTML() template.HTML {
 	return restful.HTML("%s paid army to continue expansion and reinforce army in %s.", e.Player().Name(), e.AreaName)
 }
 
-func (g *Game) invadeArea(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) invadeArea(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var (
-		a      *Area
-		armies int
-	)
-
-	if a, armies, err = g.validateInvadeArea(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
+	a, armies := g.SelectedArea(), 1+g.expansionCost()
 	cp := g.CurrentPlayer()
 	g.MultiAction = expandEmpireMA
 	a.Armies += 1
@@ -121,36 +105,33 @@ func (g *Game) invadeArea(c *gin.Context, cu *user.User) (tmpl string, act game.
 		cp.ArmySupply += 1
 	}
 	cp.PerformedAction = true
+	g.emit(cp, &AreaInvaded{Area: a.Name(), DefenderID: NoPlayerID, Success: true})
 
 	// Log Reinforcement
 	e := cp.newInvadeAreaEntry(a, armies)
 	restful.AddNoticef(c, string(e.HTML()))
 
-	tmpl, act = "atf/invade_area_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateInvadeArea(c *gin.Context, cu *user.User) (a *Area, armies int, err error) {
+func (g *Game) validateInvadeArea(cu *user.User, args *actionArgs) error {
+	return g.validateInvasion(cu, "invade-area")
+}
+
+func (g *Game) validateInvasion(cu *user.User, action string) error {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var (
-		cost int
-		cp   *Player
-	)
-
-	switch armies, cost, a, cp, err = 1, g.expansionCost(), g.SelectedArea(), g.CurrentPlayer(), g.validateExpandEmpire(c, cu); {
+	switch a, cp, err := g.SelectedArea(), g.CurrentPlayer(), g.validateExpandEmpire(cu, action); {
 	case err != nil:
+		return err
 	case !cp.hasArmyAdjacentTo(a):
-		err = sn.NewVError("You do not have an army adjacent to %s.", a.Name())
-	case cp.Army < armies:
-		err = sn.NewVError("You don't have enough armies to invade %s.", a.Name())
-	case cp.PerformedAction && g.MultiAction != expandEmpireMA:
-		err = sn.NewVError("You have already performed an action.")
-	default:
-		armies += cost
+		return sn.NewVError("You do not have an army adjacent to %s.", a.Name())
+	case cp.Army < 1:
+		return sn.NewVError("You don't have enough armies to invade %s.", a.Name())
 	}
-	return
+	return nil
 }
 
 type invadeAreaEntry struct {
@@ -166,7 +147,6 @@ func (p *Player) newInvadeAreaEntry(a *Area, armies int) *invadeAreaEntry {
 		AreaName: a.Name(),
 		Armies:   armies,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -178,69 +158,49 @@ func (e *invadeAreaEntry) HTML() template.HTML {
 	return restful.HTML("%s paid army to continue expansion and invaded %s.", e.Player().Name(), e.AreaName)
 }
 
-func (g *Game) invadeAreaWarning(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) invadeAreaWarning(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validateInvadeAreaWarning(c, cu); err != nil {
-		act, tmpl = game.None, "atf/flash_notice"
-	} else {
-		act, tmpl = game.Cache, "atf/invade_area_warning_dialog"
-	}
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateInvadeAreaWarning(c *gin.Context, cu *user.User) (err error) {
+func (g *Game) validateInvadeAreaWarning(cu *user.User, args *actionArgs) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var (
-		a  *Area
-		cp *Player
-	)
+	var a *Area
 
-	switch cp, a, err = g.CurrentPlayer(), g.SelectedArea(), g.validatePlayerAction(cu); {
+	switch a, err = g.SelectedArea(), g.validateMultiAction(cu, "invade-area-warning"); {
 	case err != nil:
 	case a == nil:
 		err = sn.NewVError("No area selected.")
 	case g.Phase != Actions:
 		err = sn.NewVError("You can't invade an area during the %q phase.", g.PhaseName())
-	case g.MultiAction != noMultiAction && g.MultiAction != expandEmpireMA:
-		err = sn.NewVError("You can't invade an area while performing a %q action.", g.MultiAction)
-	case cp.PerformedAction && g.MultiAction != expandEmpireMA:
-		err = sn.NewVError("You have already performed an action.")
 	}
 	return
 }
 
-func (g *Game) cancelInvasion(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) cancelInvasion(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validateExpandEmpire(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-	} else {
-		restful.AddNoticef(c, "%s canceled invasion of %s.", g.NameFor(g.CurrentPlayer()), g.SelectedArea().Name())
-		g.SelectedAreaID = NoArea
-		act = game.Cache
-	}
+	restful.AddNoticef(c, "%s canceled invasion of %s.", g.NameFor(g.CurrentPlayer()), g.SelectedArea().Name())
+	g.SelectedAreaID = NoArea
+	act = game.Cache
 	return
 }
 
-func (g *Game) confirmInvasion(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) validateCancelInvasion(cu *user.User, args *actionArgs) error {
+	return g.validateExpandEmpire(cu, "cancel-invasion")
+}
+
+func (g *Game) confirmInvasion(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var (
-		a      *Area
-		armies int
-	)
-
-	if a, armies, err = g.validateInvadeArea(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
+	a, armies := g.SelectedArea(), 1+g.expansionCost()
 	cp := g.CurrentPlayer()
 	if !g.Continue {
 		cp.Army -= g.expansionCost()
@@ -254,6 +214,13 @@ func (g *Game) confirmInvasion(c *gin.Context, cu *user.User) (tmpl string, act
 	}
 
 	d1, d2 := roll2D6()
+	g.emit(cp, &AreaInvaded{
+		Area:       a.Name(),
+		DefenderID: a.ArmyOwnerID,
+		Roll:       d1 + d2,
+		Needed:     success,
+		Success:    d1+d2 >= success,
+	})
 	if d1+d2 >= success {
 		a.ArmyOwner().ArmySupply += 1
 		if a.Armies == 2 {
@@ -277,6 +244,10 @@ func (g *Game) confirmInvasion(c *gin.Context, cu *user.User) (tmpl string, act
 	return
 }
 
+func (g *Game) validateConfirmInvasion(cu *user.User, args *actionArgs) error {
+	return g.validateInvasion(cu, "confirm-invasion")
+}
+
 type successfulInvasionEntry struct {
 	*Entry
 	AreaName string
@@ -296,7 +267,6 @@ func (p *Player) newSuccessfulInvasionEntry(armies, d1, d2, success int) *succes
 		D2:       d2,
 		Success:  success,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -328,7 +298,6 @@ func (p *Player) newUnsuccessfulInvasionEntry(armies, d1, d2, success int) *unsu
 		D2:       d2,
 		Success:  success,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -341,24 +310,16 @@ func (e *unsuccessfulInvasionEntry) HTML() template.HTML {
 	return restful.HTML("%s paid army to continue expansion and unsuccessfully invaded %s with a roll of %d and %d which did not satisfy the %d+ needed.", e.Player().Name(), e.AreaName, e.D1, e.D2, e.Success)
 }
 
-func (g *Game) destroyCity(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) destroyCity(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var (
-		a        *Area
-		armies   int
-		expanded bool
-	)
-
-	if a, armies, expanded, err = g.validateDestroyCity(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
+	a := g.SelectedArea()
+	armies, expanded := g.expansionCost()+g.destructionCostIn(a), g.expansionCost() > 0
 	cp := g.CurrentPlayer()
 	g.MultiAction = expandEmpireMA
 	owner := a.City.Owner()
+	g.emit(cp, &CityDestroyed{Area: a.Name(), OwnerID: owner.ID(), Expanded: a.City.Expanded})
 	owner.City += 1
 	g.OtherPlayer = owner
 	if a.City.Expanded {
@@ -376,34 +337,30 @@ func (g *Game) destroyCity(c *gin.Context, cu *user.User) (tmpl string, act game
 	e := cp.newDestroyCityEntry(a, armies, owner, expanded)
 	restful.AddNoticef(c, string(e.HTML()))
 
-	tmpl, act = "atf/destroy_city_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateDestroyCity(c *gin.Context, cu *user.User) (a *Area, armies int, expanded bool, err error) {
+func (g *Game) validateDestroyCity(cu *user.User, args *actionArgs) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validatePlayerAction(cu); err != nil {
+	if err = g.validateMultiAction(cu, "destroy-city"); err != nil {
 		return
 	}
 
-	a = g.SelectedArea()
-	cp := g.CurrentPlayer()
-	armies = g.expansionCost() + g.destructionCostIn(a)
-	if g.expansionCost() > 0 {
-		expanded = true
+	a := g.SelectedArea()
+	if a == nil {
+		return sn.NewVError("No area selected.")
 	}
 
+	cp := g.CurrentPlayer()
+	armies := g.expansionCost() + g.destructionCostIn(a)
 	switch {
-	case a == nil:
-		err = sn.NewVError("No area selected.")
 	case !cp.hasArmyIn(a):
 		err = sn.NewVError("You do not have an army adjacent to %s.", a.Name())
 	case cp.Army < armies:
 		err = sn.NewVError("You don't have enough armies to invade %s.", a.Name())
-	case cp.PerformedAction && g.MultiAction != expandEmpireMA:
-		err = sn.NewVError("You have already performed an action.")
 	}
 	return
 }
@@ -424,7 +381,6 @@ func (p *Player) newDestroyCityEntry(a *Area, armies int, op *Player, expanded b
 		Expanded: expanded,
 	}
 	e.SetOtherPlayer(op)
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -438,25 +394,18 @@ func (e *destroyCityEntry) HTML() template.HTML {
 		e.Player().Name(), e.Armies, e.OtherPlayer().Name(), e.AreaName)
 }
 
-func (g *Game) validateExpandEmpire(c *gin.Context, cu *user.User) (err error) {
+func (g *Game) validateExpandEmpire(cu *user.User, action string) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var (
-		a  *Area
-		cp *Player
-	)
+	var a *Area
 
-	switch a, cp, err = g.SelectedArea(), g.CurrentPlayer(), g.validatePlayerAction(cu); {
+	switch a, err = g.SelectedArea(), g.validateMultiAction(cu, action); {
 	case err != nil:
 	case a == nil:
 		err = sn.NewVError("No area selected.")
 	case g.Phase != Actions:
 		err = sn.NewVError("You can't expand empire during the %q phase.", g.PhaseName())
-	case g.MultiAction != noMultiAction && g.MultiAction != expandEmpireMA:
-		err = sn.NewVError("You can't expand empire while performing a %q action.", g.MultiAction)
-	case cp.PerformedAction && g.MultiAction != expandEmpireMA:
-		err = sn.NewVError("You have already performed an action.")
 	}
 	return
 }
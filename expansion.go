@@ -0,0 +1,212 @@
+package atf
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// expansionTarget is an area the empire of a player can reach by a series of expansions.
+type expansionTarget struct {
+	Area string   `json:"area"`
+	Path []string `json:"path"`
+	// Cost is the total number of armies spent expanding along the path.
+	Cost int `json:"cost"`
+	// Occupied targets are held by the army of DefenderID and must be invaded.
+	// Cost then excludes armies lost to failed rolls, and SuccessChance is the chance of each roll succeeding.
+	DefenderID    int     `json:"defenderId"`
+	Defenders     int     `json:"defenders,omitempty"`
+	SuccessChance float64 `json:"successChance,omitempty"`
+	// DestroyCost is the total number of armies needed to reach the area and destroy its city, if any.
+	DestroyCost int  `json:"destroyCost,omitempty"`
+	Affordable  bool `json:"affordable"`
+}
+
+type reinforcement struct {
+	Area       string `json:"area"`
+	Cost       int    `json:"cost"`
+	Affordable bool   `json:"affordable"`
+}
+
+type destruction struct {
+	Area       string `json:"area"`
+	OwnerID    int    `json:"ownerId"`
+	Cost       int    `json:"cost"`
+	Fortified  bool   `json:"fortified"`
+	Affordable bool   `json:"affordable"`
+}
+
+// stepCost returns the armies spent by the n-th expansion (from 1) of a series.
+// Each expansion after the first of a turn costs an additional army.
+func (g *Game) stepCost(n int) int {
+	if n == 1 {
+		return 1 + g.expansionCost()
+	}
+	return 2
+}
+
+// invasionSuccessChance returns the chance a roll of 2d6 invading the army of defender succeeds.
+func (p *Player) invasionSuccessChance(defender *Player) float64 {
+	needed := 5
+	if de, pe := defender.empire(), p.empire(); de != nil && pe != nil && de.Rating > pe.Rating {
+		needed = 7
+	}
+
+	ways := 0
+	for d1 := 1; d1 <= 6; d1++ {
+		for d2 := 1; d2 <= 6; d2++ {
+			if d1+d2 >= needed {
+				ways += 1
+			}
+		}
+	}
+	return float64(ways) / 36
+}
+
+// expansionTargets returns the areas reachable by the empire of p, with the cheapest path to each.
+// Paths pass only through unoccupied areas, which are occupied in turn.
+func (p *Player) expansionTargets() []expansionTarget {
+	g := p.Game()
+	empire := p.empire()
+	if empire == nil {
+		return nil
+	}
+
+	type node struct {
+		aid  AreaID
+		path []string
+	}
+
+	visited := make(map[AreaID]bool)
+	var frontier []node
+	for _, a := range g.Areas {
+		if p.hasArmyIn(a) {
+			visited[a.ID] = true
+			frontier = append(frontier, node{aid: a.ID})
+		}
+	}
+
+	var ts []expansionTarget
+	visit := func(a *Area, path []string, steps int) bool {
+		if visited[a.ID] {
+			return false
+		}
+		visited[a.ID] = true
+
+		t := expansionTarget{Area: a.Name(), Path: append(append([]string(nil), path...), a.Name()), DefenderID: NoPlayerID}
+		for i := 1; i <= steps; i++ {
+			t.Cost += g.stepCost(i)
+		}
+
+		occupied := false
+		if defender := a.ArmyOwner(); defender != nil {
+			occupied = true
+			t.DefenderID, t.Defenders = defender.ID(), a.Armies
+			t.SuccessChance = p.invasionSuccessChance(defender)
+			if steps == 1 && g.Continue {
+				t.Cost -= g.expansionCost()
+			}
+		}
+
+		if a.City.Built && !p.hasCityIn(a.ID) {
+			t.DestroyCost = t.Cost + 1 + g.destructionCostIn(a)
+		}
+		t.Affordable = p.Army >= t.Cost
+		ts = append(ts, t)
+		return !occupied
+	}
+
+	// without armies on the board, the empire expands into its starting area
+	steps := 1
+	if len(frontier) == 0 {
+		steps = 2
+		for _, a := range g.Areas {
+			if a.ID == empire.AreaID || (empire.AreaID == Sumer && a.IsSumer()) {
+				if visit(a, nil, 1) {
+					frontier = append(frontier, node{aid: a.ID, path: []string{a.Name()}})
+				}
+			}
+		}
+	}
+
+	for ; len(frontier) > 0; steps++ {
+		var next []node
+		for _, n := range frontier {
+			for _, a := range g.areasAdjacentTo(g.Areas[n.aid]) {
+				if visit(a, n.path, steps) {
+					next = append(next, node{aid: a.ID, path: append(append([]string(nil), n.path...), a.Name())})
+				}
+			}
+		}
+		frontier = next
+	}
+	return ts
+}
+
+// reinforcements returns the areas in which p may add a second army.
+func (p *Player) reinforcements() []reinforcement {
+	g := p.Game()
+	var rs []reinforcement
+	for _, a := range g.Areas {
+		if p.ArmiesIn(a) == 1 {
+			cost := g.stepCost(1)
+			rs = append(rs, reinforcement{Area: a.Name(), Cost: cost, Affordable: p.Army >= cost})
+		}
+	}
+	return rs
+}
+
+// destructions returns the cities p may destroy with the armies already on the board.
+func (p *Player) destructions() []destruction {
+	g := p.Game()
+	var ds []destruction
+	for _, a := range g.Areas {
+		if p.hasArmyIn(a) && a.City.Built && !p.hasCityIn(a.ID) {
+			cost := g.expansionCost() + g.destructionCostIn(a)
+			ds = append(ds, destruction{
+				Area:       a.Name(),
+				OwnerID:    a.City.OwnerID,
+				Cost:       cost,
+				Fortified:  a.City.Owner().privilegeBonus(onDestruction) > 0,
+				Affordable: p.Army >= cost,
+			})
+		}
+	}
+	return ds
+}
+
+// expansionCalculator reports the army costs of expanding the empire of a player (by default, the current user).
+func (client *Client) expansionCalculator(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		p, err := client.requestedPlayer(c, g)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if p.empire() == nil {
+			c.JSON(http.StatusOK, gin.H{"playerId": p.ID(), "empire": nil})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"playerId":       p.ID(),
+			"empire":         p.empire().AreaID.Name(),
+			"armies":         p.Army,
+			"expanding":      g.MultiAction == expandEmpireMA,
+			"continue":       g.Continue,
+			"targets":        p.expansionTargets(),
+			"reinforcements": p.reinforcements(),
+			"destructions":   p.destructions(),
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package atf
+
+import "testing"
+
+func TestStepCost(t *testing.T) {
+	tests := []struct {
+		name string
+		ma   MultiActionID
+		n    int
+		want int
+	}{
+		{"first expansion", noMultiAction, 1, 1},
+		{"second expansion", noMultiAction, 2, 2},
+		{"third expansion", noMultiAction, 3, 2},
+		{"first continued expansion", expandEmpireMA, 1, 2},
+		{"second continued expansion", expandEmpireMA, 2, 2},
+	}
+
+	for _, test := range tests {
+		g := newTestGame()
+		g.MultiAction = test.ma
+		if got := g.stepCost(test.n); got != test.want {
+			t.Errorf("%s: stepCost(%d) = %d; want %d", test.name, test.n, got, test.want)
+		}
+	}
+}
+
+func TestDestroyCost(t *testing.T) {
+	tests := []struct {
+		name string
+		// city is the area of the city of the second player
+		city AreaID
+		// fortified gives the second player the Shuruppak city with 2 cities left in supply
+		fortified bool
+		want      int
+	}{
+		// expansion cost 1, occupying army 1, destruction cost 2 (3 fortified)
+		{"adjacent", Eridu, false, 4},
+		{"adjacent fortified", Eridu, true, 5},
+		// expansion cost 1 + 2, occupying army 1, destruction cost 2 (3 fortified)
+		{"two steps", Nippur, false, 6},
+		{"two steps fortified", Nippur, true, 7},
+	}
+
+	for _, test := range tests {
+		g := newTestGame()
+		g.setupEmpireTable()
+		g.Turn = 1
+		p, owner := g.Players()[0], g.Players()[1]
+		p.Army = 20
+		g.CurrentEmpires()[0].OwnerID = p.ID()
+
+		lagash := g.Areas[Lagash]
+		lagash.ArmyOwnerID, lagash.Armies = p.ID(), 1
+
+		g.Areas[test.city].City.Built = true
+		g.Areas[test.city].City.setOwner(owner)
+		if test.fortified {
+			g.Areas[Shuruppak].City.Built = true
+			g.Areas[Shuruppak].City.setOwner(owner)
+			owner.City = 2
+		}
+
+		got := -1
+		for _, target := range p.expansionTargets() {
+			if target.Area == g.Areas[test.city].Name() {
+				got = target.DestroyCost
+			}
+		}
+		if got != test.want {
+			t.Errorf("%s: DestroyCost = %d; want %d", test.name, got, test.want)
+		}
+	}
+}
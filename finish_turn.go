@@ -1,6 +1,7 @@
 package atf
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -45,10 +46,20 @@ func (client *Client) finish(prefix string) gin.HandlerFunc {
 			c.Redirect(http.StatusSeeOther, showPath(prefix, c.Param(hParam)))
 			return
 		}
+		g.recordOutcome(c, cu, "", showPath(prefix, c.Param(hParam)))
 		err = client.saveWith(c, g, cu, ks, es)
+		if err != nil {
+			g.forgetOutcome(c, cu)
+		}
+		if errors.Is(err, ErrConflict) {
+			client.Log.Warningf(err.Error())
+			client.conflict(c, prefix)
+			return
+		}
 		if err != nil {
 			client.Log.Errorf(err.Error())
 			restful.AddErrorf(c, err.Error())
+			c.Redirect(http.StatusSeeOther, showPath(prefix, c.Param(hParam)))
 			return
 		}
 		c.Redirect(http.StatusSeeOther, showPath(prefix, c.Param(hParam)))
@@ -120,12 +131,12 @@ func (client *Client) actionsPhaseFinishTurn(c *gin.Context, g *Game, cu *user.U
 			return []*datastore.Key{s.Key}, []interface{}{s}, nil
 		}
 
-		if g.Turn == 5 {
+		if g.Turn == g.lastTurn() {
 			cs, err := client.endGameScoring(c, g)
 			if err != nil {
 				return nil, nil, err
 			}
-			ks, es := wrap(s.GetUpdate(c, time.Time(g.UpdatedAt)), cs)
+			ks, es := wrap(s.GetUpdate(c, time.Time(g.UpdatedAt)), g.variant(), cs)
 			return ks, es, nil
 		} else {
 			g.endOfTurn(c)
@@ -203,12 +214,12 @@ func (client *Client) expandCityPhaseFinishTurn(c *gin.Context, g *Game, cu *use
 	np := g.expandCityPhaseNextPlayer()
 	if np != nil {
 		g.setCurrentPlayers(np)
-	} else if g.Turn == 5 {
+	} else if g.Turn == g.lastTurn() {
 		cs, err := client.endGameScoring(c, g)
 		if err != nil {
 			return nil, nil, err
 		}
-		ks, es := wrap(s.GetUpdate(c, time.Time(g.UpdatedAt)), cs)
+		ks, es := wrap(s.GetUpdate(c, time.Time(g.UpdatedAt)), g.variant(), cs)
 		return ks, es, nil
 	} else {
 		g.endOfTurn(c)
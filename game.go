@@ -3,6 +3,7 @@ package atf
 import (
 	"encoding/gob"
 	"errors"
+	"fmt"
 	"html/template"
 	"math/rand"
 	"time"
@@ -48,14 +49,25 @@ type Game struct {
 }
 
 type State struct {
-	Playerers      game.Playerers
-	Log            game.GameLog
+	Playerers game.Playerers
+
+	// Log holds entries not yet moved to log pages.
+	// LogLength is the number of entries persisted in log pages.
+	Log       game.GameLog
+	LogLength int
+
 	Resources      Resources `form:"resources"`
 	Areas          Areas
 	EmpireTable    EmpireTable
 	Continue       bool
 	MultiAction    MultiActionID
 	SelectedAreaID AreaID
+	Outcomes       outcomes
+	SnapshotCount  int
+	EmpireSeed     int64
+	PrivilegeSeed  int64
+	Privileges     privilegeSet
+	Events         []Event `json:"-"`
 }
 
 func (g *Game) GetPlayerers() game.Playerers {
@@ -103,31 +115,51 @@ func (g *Game) setupPhase(c *gin.Context) {
 	g.Phase = Setup
 	g.addNewPlayers()
 	g.createAreas()
-	g.EmpireTable = defaultEmpireTable()
-	g.initEmpireTable()
-	g.Resources = Resources{0, 9, 9, 0, 9, 4, 4, 7}
+	g.setupEmpireTable()
+	g.setupPrivileges()
+	g.Resources = g.variant().supply()
 	g.RandomTurnOrder()
 	for _, p := range g.Players() {
 		p.newSetupEntry()
 	}
+	if g.variant()["empires"] == "random" {
+		g.newEmpireTableEntry()
+	}
+	if g.variant()["privileges"] == shuffledPrivilegeSet {
+		g.newPrivilegesEntry()
+	}
 	g.start(c)
 }
 
 type setupEntry struct {
 	*Entry
+	Resources Resources
+	Workers   int
 }
 
 func (p *Player) newSetupEntry() *setupEntry {
 	g := p.Game()
 	e := new(setupEntry)
 	e.Entry = p.newEntry()
-	p.Log = append(p.Log, e)
+	e.Resources = append(Resources(nil), p.Resources...)
+	e.Workers = p.Worker
 	g.Log = append(g.Log, e)
 	return e
 }
 
 func (e *setupEntry) HTML() template.HTML {
-	return restful.HTML("%s received 1 wood, 1 metal, 1 tool, 1 oil, 1 gold, and 2 workers.", e.Player().Name())
+	if e.Resources == nil {
+		return restful.HTML("%s received 1 wood, 1 metal, 1 tool, 1 oil, 1 gold, and 2 workers.", e.Player().Name())
+	}
+
+	var ss []string
+	for i, cnt := range e.Resources {
+		if cnt > 0 {
+			ss = append(ss, fmt.Sprintf("%d %s", cnt, Resource(i).LString()))
+		}
+	}
+	ss = append(ss, fmt.Sprintf("%d workers", e.Workers))
+	return restful.HTML("%s received %s.", e.Player().Name(), restful.ToSentence(ss))
 }
 
 func (g *Game) start(c *gin.Context) {
@@ -138,19 +170,27 @@ func (g *Game) start(c *gin.Context) {
 
 type startEntry struct {
 	*Entry
+	Variant string
 }
 
 func (g *Game) newStartEntry() *startEntry {
 	e := new(startEntry)
 	e.Entry = g.newEntry()
+	if v := g.variant(); !v.standard() {
+		e.Variant = v.String()
+	}
 	g.Log = append(g.Log, e)
 	return e
 }
 
 func (e *startEntry) HTML() template.HTML {
 	g := e.Game()
-	return restful.HTML("Good luck %s, %s, and %s.  Have fun.",
+	s := restful.HTML("Good luck %s, %s, and %s.  Have fun.",
 		g.NameFor(g.Players()[0]), g.NameFor(g.Players()[1]), g.NameFor(g.Players()[2]))
+	if e.Variant != "" {
+		s += restful.HTML("<div>Variant: %s.</div>", e.Variant)
+	}
+	return s
 }
 
 func (g *Game) startTurn(c *gin.Context) {
@@ -161,13 +201,8 @@ func (g *Game) startTurn(c *gin.Context) {
 	g.setCurrentPlayers(cp)
 	g.beginningOfPhaseReset()
 	g.newStartTurnEntry()
-	g.collectGrainPhase(c)
-	g.collectTextilePhase(c)
-	g.collectWorkersPhase(c)
-	g.resetScribesPhase(c)
-	g.resetToolMakersPhase(c)
-	g.declinePhase(c)
-	g.actionsPhase(c)
+	g.emit(nil, new(TurnStarted))
+	g.runPipeline(c, startTurnPipeline)
 }
 
 type startTurnEntry struct {
@@ -272,15 +307,16 @@ func (g *Game) CurrentPlayer() *Player {
 	return nil
 }
 
-func (g *Game) adminSupplyTable(c *gin.Context, cu *user.User) (string, game.ActionType, error) {
+type adminSupplyTableForm struct {
+	Resources Resources `form:"resources"`
+}
+
+func (g *Game) adminSupplyTable(c *gin.Context, cu *user.User, args *actionArgs) (string, game.ActionType, error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	ns := struct {
-		Resources Resources `form:"resources"`
-	}{}
-	ns.Resources = Resources{0, 9, 9, 0, 9, 4, 4, 7}
-	err := c.ShouldBind(&ns)
+	ns := adminSupplyTableForm{Resources: Resources{0, 9, 9, 0, 9, 4, 4, 7}}
+	err := args.bind(&ns)
 	if err != nil {
 		return "", game.None, err
 	}
@@ -311,37 +347,38 @@ func (g *Game) anyPassed() bool {
 	return g.Players()[0].Passed || g.Players()[1].Passed || g.Players()[2].Passed
 }
 
-func (g *Game) adminHeader(c *gin.Context, cu *user.User) (string, game.ActionType, error) {
+type adminHeaderForm struct {
+	Title         string           `form:"title"`
+	Turn          int              `form:"turn" binding:"min=0"`
+	Phase         game.Phase       `form:"phase" binding:"min=0"`
+	SubPhase      game.SubPhase    `form:"sub-phase" binding:"min=0"`
+	Round         int              `form:"round" binding:"min=0"`
+	NumPlayers    int              `form:"num-players" binding"min=0,max=5"`
+	Password      string           `form:"password"`
+	CreatorID     int64            `form:"creator-id"`
+	CreatorSID    string           `form:"creator-sid"`
+	CreatorName   string           `form:"creator-name"`
+	UserIDS       []int64          `form:"user-ids"`
+	UserSIDS      []string         `form:"user-sids"`
+	UserNames     []string         `form:"user-names"`
+	UserEmails    []string         `form:"user-emails"`
+	OrderIDS      game.UserIndices `form:"order-ids"`
+	CPUserIndices game.UserIndices `form:"cp-user-indices"`
+	WinnerIDS     game.UserIndices `form:"winner-ids"`
+	Status        game.Status      `form:"status"`
+	Progress      string           `form:"progress"`
+	Options       []string         `form:"options"`
+	OptString     string           `form:"opt-string"`
+	CreatedAt     time.Time        `form:"created-at"`
+	UpdatedAt     time.Time        `form:"updated-at"`
+}
+
+func (g *Game) adminHeader(c *gin.Context, cu *user.User, args *actionArgs) (string, game.ActionType, error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	h := struct {
-		Title         string           `form:"title"`
-		Turn          int              `form:"turn" binding:"min=0"`
-		Phase         game.Phase       `form:"phase" binding:"min=0"`
-		SubPhase      game.SubPhase    `form:"sub-phase" binding:"min=0"`
-		Round         int              `form:"round" binding:"min=0"`
-		NumPlayers    int              `form:"num-players" binding"min=0,max=5"`
-		Password      string           `form:"password"`
-		CreatorID     int64            `form:"creator-id"`
-		CreatorSID    string           `form:"creator-sid"`
-		CreatorName   string           `form:"creator-name"`
-		UserIDS       []int64          `form:"user-ids"`
-		UserSIDS      []string         `form:"user-sids"`
-		UserNames     []string         `form:"user-names"`
-		UserEmails    []string         `form:"user-emails"`
-		OrderIDS      game.UserIndices `form:"order-ids"`
-		CPUserIndices game.UserIndices `form:"cp-user-indices"`
-		WinnerIDS     game.UserIndices `form:"winner-ids"`
-		Status        game.Status      `form:"status"`
-		Progress      string           `form:"progress"`
-		Options       []string         `form:"options"`
-		OptString     string           `form:"opt-string"`
-		CreatedAt     time.Time        `form:"created-at"`
-		UpdatedAt     time.Time        `form:"updated-at"`
-	}{}
-
-	err := c.ShouldBind(&h)
+	var h adminHeaderForm
+	err := args.bind(&h)
 	if err != nil {
 		return "", game.None, err
 	}
@@ -0,0 +1,54 @@
+package atf
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cachedGame is a fully initialized game cached for the version identified by UpdatedAt.
+// The game is never handed to a request; each request receives its own copy.
+type cachedGame struct {
+	UpdatedAt time.Time
+	Game      *Game
+}
+
+func cachedGameKey(id int64) string {
+	return fmt.Sprintf("atf-game-%d", id)
+}
+
+// fromCache returns a private copy of the cached game with the provided id.
+// Saves invalidate the cached game, so the cached version is the stored version.
+func (client *Client) fromCache(c *gin.Context, id int64) (*Game, bool, error) {
+	item, found := client.Cache.Get(cachedGameKey(id))
+	if !found {
+		return nil, false, nil
+	}
+
+	cg, ok := item.(*cachedGame)
+	if !ok {
+		return nil, false, nil
+	}
+
+	g, err := client.deepCopy(c, cg.Game)
+	if err != nil {
+		return nil, false, err
+	}
+	return g, true, nil
+}
+
+// cacheGame caches a copy of g, so later changes to g by the request do not reach the cache.
+func (client *Client) cacheGame(c *gin.Context, g *Game) error {
+	g2, err := client.deepCopy(c, g)
+	if err != nil {
+		return err
+	}
+
+	client.Cache.SetDefault(cachedGameKey(g.ID()), &cachedGame{UpdatedAt: g.UpdatedAt, Game: g2})
+	return nil
+}
+
+func (client *Client) uncacheGame(g *Game) {
+	client.Cache.Delete(cachedGameKey(g.ID()))
+}
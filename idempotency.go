@@ -0,0 +1,117 @@
+package atf
+
+import (
+	"net/http"
+
+	"github.com/SlothNinja/game"
+	"github.com/SlothNinja/restful"
+	"github.com/SlothNinja/sn"
+	"github.com/SlothNinja/user"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyHeader  = "Idempotency-Key"
+	idempotencyParam   = "idempotency-key"
+	maxIdempotencyKeys = 20
+)
+
+// outcome records how a request submitted with an idempotency key was answered,
+// so that a repeat of the request can be answered the same way without re-executing it.
+type outcome struct {
+	Key      string
+	UserID   int64
+	Template string
+	Location string
+}
+
+type outcomes []*outcome
+
+func idempotencyKeyFrom(c *gin.Context) string {
+	if k := c.GetHeader(idempotencyHeader); k != "" {
+		return k
+	}
+	return c.PostForm(idempotencyParam)
+}
+
+func (g *Game) outcomeFor(cu *user.User, key string) *outcome {
+	if cu == nil || key == "" {
+		return nil
+	}
+	for _, o := range g.Outcomes {
+		if o.Key == key && o.UserID == cu.ID() {
+			return o
+		}
+	}
+	return nil
+}
+
+// recordOutcome records the outcome of the request, if the request provided an idempotency key.
+// Only the most recent maxIdempotencyKeys outcomes are retained.
+func (g *Game) recordOutcome(c *gin.Context, cu *user.User, tmpl, location string) {
+	key := idempotencyKeyFrom(c)
+	if cu == nil || key == "" {
+		return
+	}
+
+	g.Outcomes = append(g.Outcomes, &outcome{Key: key, UserID: cu.ID(), Template: tmpl, Location: location})
+	if l := len(g.Outcomes); l > maxIdempotencyKeys {
+		g.Outcomes = g.Outcomes[l-maxIdempotencyKeys:]
+	}
+}
+
+// forgetOutcome removes the outcome recorded for the request, e.g., when the update could not be saved.
+func (g *Game) forgetOutcome(c *gin.Context, cu *user.User) {
+	o := g.outcomeFor(cu, idempotencyKeyFrom(c))
+	if o == nil {
+		return
+	}
+	for i, o2 := range g.Outcomes {
+		if o2 == o {
+			g.Outcomes = append(g.Outcomes[:i], g.Outcomes[i+1:]...)
+			return
+		}
+	}
+}
+
+// idempotent replays the recorded outcome of a repeated request instead of re-executing it.
+func (client *Client) idempotent(c *gin.Context) {
+	client.Log.Debugf(msgEnter)
+	defer client.Log.Debugf(msgExit)
+
+	g := gameFrom(c)
+	if g == nil {
+		return
+	}
+
+	cu, err := client.User.Current(c)
+	if err != nil {
+		client.Log.Debugf(err.Error())
+	}
+
+	o := g.outcomeFor(cu, idempotencyKeyFrom(c))
+	if o == nil {
+		return
+	}
+
+	client.Log.Debugf("replaying outcome for idempotency key %q", o.Key)
+	restful.AddNoticef(c, "Your request was already processed.")
+	switch {
+	case o.Location != "":
+		c.Redirect(http.StatusSeeOther, o.Location)
+	case o.Template == "json":
+		c.JSON(http.StatusOK, g)
+	default:
+		c.HTML(http.StatusOK, o.Template, gin.H{
+			"Context":   c,
+			"VersionID": sn.VersionID(),
+			"CUser":     cu,
+			"Game":      g,
+			"Admin":     game.AdminFrom(c),
+			"IsAdmin":   cu.IsAdmin(),
+			"Notices":   restful.NoticesFrom(c),
+			"Errors":    restful.ErrorsFrom(c),
+		})
+	}
+	c.Abort()
+}
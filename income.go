@@ -0,0 +1,168 @@
+package atf
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxWhatIfWorkers = 10
+
+var errNoPlayer = errors.New("No such player in this game.")
+
+// income is the grain, textile and workers a player is projected to collect at the start of the next turn.
+type income struct {
+	PlayerID int    `json:"playerId"`
+	Name     string `json:"name"`
+	Grain    int    `json:"grain"`
+	Textile  int    `json:"textile"`
+	Workers  int    `json:"workers"`
+}
+
+// incomeChange reports a projected income and its change from the current projection.
+type incomeChange struct {
+	income
+	GrainDelta   int `json:"grainDelta"`
+	TextileDelta int `json:"textileDelta"`
+	WorkersDelta int `json:"workersDelta"`
+}
+
+// projectedIncome returns the income of p at the start of the next turn, given the current board.
+// Workers not placed by the end of the turn return to the supply before workers are collected.
+func (p *Player) projectedIncome() income {
+	return income{
+		PlayerID: p.ID(),
+		Name:     p.Game().NameFor(p),
+		Grain:    p.grainIncome(),
+		Textile:  p.textileCollected(),
+		Workers:  min(baseWorkerIncome, p.WorkerSupply+p.Worker),
+	}
+}
+
+func (g *Game) projectedIncomes() []income {
+	is := make([]income, len(g.Players()))
+	for i, p := range g.Players() {
+		is[i] = p.projectedIncome()
+	}
+	return is
+}
+
+// incomeIfPlaced returns the projected incomes of all players were p to place n more workers in the area.
+// g must be a copy of the game, as the workers are placed to compute the projection.
+// n must not exceed the workers p has available to place.
+func (g *Game) incomeIfPlaced(p *Player, aid AreaID, n int) []incomeChange {
+	before := g.projectedIncomes()
+
+	p.incWorkersIn(g.Areas[aid], n)
+	p.Worker -= n
+
+	after := g.projectedIncomes()
+	ics := make([]incomeChange, len(after))
+	for i, in := range after {
+		ics[i] = incomeChange{
+			income:       in,
+			GrainDelta:   in.Grain - before[i].Grain,
+			TextileDelta: in.Textile - before[i].Textile,
+			WorkersDelta: in.Workers - before[i].Workers,
+		}
+	}
+	return ics
+}
+
+// incomeProjection reports the income each player is projected to collect next turn.
+// Given area (irrigation or weaving) and workers parameters, it also reports the projection
+// were the player (by default, the current user) to place that many more workers in the area.
+func (client *Client) incomeProjection(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		h := gin.H{
+			"turn":       g.Turn,
+			"projection": g.projectedIncomes(),
+		}
+
+		area := strings.ToLower(c.Query("area"))
+		if area == "" {
+			c.JSON(http.StatusOK, h)
+			return
+		}
+
+		var aid AreaID
+		switch area {
+		case "irrigation":
+			aid = Irrigation
+		case "weaving":
+			aid = Weaving
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "area must be irrigation or weaving"})
+			return
+		}
+
+		n, err := strconv.Atoi(c.DefaultQuery("workers", "1"))
+		if err != nil || n < 1 || n > maxWhatIfWorkers {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "workers must be between 1 and " + strconv.Itoa(maxWhatIfWorkers)})
+			return
+		}
+
+		// the game may be shared with other requests, so the placement is made on a copy
+		g2, err := client.deepCopy(c, g)
+		if err != nil {
+			client.Log.Errorf(err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		p, err := client.requestedPlayer(c, g2)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if n > p.Worker {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s has only %d workers to place", g2.NameFor(p), p.Worker)})
+			return
+		}
+
+		h["whatIf"] = gin.H{
+			"playerId":   p.ID(),
+			"area":       aid.Name(),
+			"workers":    n,
+			"projection": g2.incomeIfPlaced(p, aid, n),
+		}
+		c.JSON(http.StatusOK, h)
+	}
+}
+
+// requestedPlayer returns the player given by the player parameter or, by default, the player of the current user.
+func (client *Client) requestedPlayer(c *gin.Context, g *Game) (*Player, error) {
+	if v := c.Query("player"); v != "" {
+		pid, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		if p := g.PlayerByID(pid); p != nil {
+			return p, nil
+		}
+		return nil, errNoPlayer
+	}
+
+	cu, err := client.User.Current(c)
+	if err != nil || cu == nil {
+		return nil, errNoPlayer
+	}
+	if p := g.PlayerByUserID(cu.ID()); p != nil {
+		return p, nil
+	}
+	return nil, errNoPlayer
+}
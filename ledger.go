@@ -0,0 +1,193 @@
+package atf
+
+import (
+	"encoding/gob"
+	"html/template"
+	"net/http"
+
+	"github.com/SlothNinja/restful"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gob.Register(new(scoreBreakdownEntry))
+}
+
+type scoreCategory int
+
+const (
+	empireScore scoreCategory = iota
+	privilegeScore
+	cityExpansionScore
+	workerMajorityScore
+	adjustedScore
+)
+
+var scoreCategoryNames = map[scoreCategory]string{
+	empireScore:         "Empire Areas",
+	privilegeScore:      "Privilege Bonus",
+	cityExpansionScore:  "City Expansions",
+	workerMajorityScore: "Worker Majorities",
+	adjustedScore:       "Adjustments",
+}
+
+func (sc scoreCategory) String() string {
+	return scoreCategoryNames[sc]
+}
+
+func scoreCategories() []scoreCategory {
+	return []scoreCategory{empireScore, privilegeScore, cityExpansionScore, workerMajorityScore, adjustedScore}
+}
+
+// scoreItem records points a player scored.
+type scoreItem struct {
+	Turn     int
+	Category scoreCategory
+	AreaID   AreaID
+	Points   int
+}
+
+type scoreLedger []scoreItem
+
+// addScore adds points to the score of p and records them in the ledger of p.
+func (p *Player) addScore(category scoreCategory, aid AreaID, points int) {
+	p.Score += points
+	p.Ledger = append(p.Ledger, scoreItem{
+		Turn:     p.Game().Turn,
+		Category: category,
+		AreaID:   aid,
+		Points:   points,
+	})
+}
+
+// openLedger records the score of p as an adjustment if p has a score, but no ledger,
+// e.g., a player of a game started before scores were recorded in ledgers.
+func (p *Player) openLedger() {
+	if len(p.Ledger) > 0 || p.Score == 0 {
+		return
+	}
+	p.Ledger = scoreLedger{{
+		Turn:     p.Game().Turn,
+		Category: adjustedScore,
+		AreaID:   NoArea,
+		Points:   p.Score,
+	}}
+}
+
+// byCategory returns the points of the ledger totalled by category.
+func (l scoreLedger) byCategory() map[scoreCategory]int {
+	totals := make(map[scoreCategory]int, len(scoreCategoryNames))
+	for _, item := range l {
+		totals[item.Category] += item.Points
+	}
+	return totals
+}
+
+type scoreBreakdownEntry struct {
+	*Entry
+	Totals [][]int
+	Scores []int
+}
+
+// newScoreBreakdownEntry logs the scores of the players totalled by category.
+func (g *Game) newScoreBreakdownEntry() {
+	e := &scoreBreakdownEntry{
+		Entry:  g.newEntry(),
+		Totals: make([][]int, g.NumPlayers),
+		Scores: make([]int, g.NumPlayers),
+	}
+	for _, p := range g.Players() {
+		totals := p.Ledger.byCategory()
+		for _, sc := range scoreCategories() {
+			e.Totals[p.ID()] = append(e.Totals[p.ID()], totals[sc])
+		}
+		e.Scores[p.ID()] = p.Score
+	}
+	g.Log = append(g.Log, e)
+}
+
+func (e *scoreBreakdownEntry) HTML() template.HTML {
+	g := e.Game()
+	s := restful.HTML("<div>Final scores by category:</div><div>&nbsp;</div>")
+	s += restful.HTML("<table class='strippedDataTable'><thead><tr><th>Category</th>")
+	for pid := range e.Scores {
+		s += restful.HTML("<th>%s</th>", g.NameByPID(pid))
+	}
+	s += restful.HTML("</tr></thead><tbody>")
+	for i, sc := range scoreCategories() {
+		row := restful.HTML("<tr><td>%s</td>", sc)
+		points := false
+		for pid := range e.Scores {
+			v := 0
+			if i < len(e.Totals[pid]) {
+				v = e.Totals[pid][i]
+			}
+			points = points || v != 0
+			row += restful.HTML("<td>%d</td>", v)
+		}
+		if points {
+			s += row + restful.HTML("</tr>")
+		}
+	}
+	s += restful.HTML("<tr><td>Total</td>")
+	for _, score := range e.Scores {
+		s += restful.HTML("<td>%d</td>", score)
+	}
+	s += restful.HTML("</tr></tbody></table>")
+	return s
+}
+
+type jScoreItem struct {
+	Turn     int    `json:"turn"`
+	Category string `json:"category"`
+	Area     string `json:"area,omitempty"`
+	Points   int    `json:"points"`
+}
+
+type jLedger struct {
+	PlayerID   int            `json:"playerId"`
+	Name       string         `json:"name"`
+	Score      int            `json:"score"`
+	ByCategory map[string]int `json:"byCategory"`
+	ByTurn     map[int]int    `json:"byTurn"`
+	Items      []jScoreItem   `json:"items"`
+}
+
+// scoreBreakdown provides the scoring events of each player, totalled by category and turn.
+func (client *Client) scoreBreakdown(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		ledgers := make([]jLedger, 0, g.NumPlayers)
+		for _, p := range g.Players() {
+			l := jLedger{
+				PlayerID:   p.ID(),
+				Name:       g.NameFor(p),
+				Score:      p.Score,
+				ByCategory: make(map[string]int),
+				ByTurn:     make(map[int]int),
+				Items:      make([]jScoreItem, 0, len(p.Ledger)),
+			}
+			for sc, points := range p.Ledger.byCategory() {
+				l.ByCategory[sc.String()] = points
+			}
+			for _, item := range p.Ledger {
+				l.ByTurn[item.Turn] += item.Points
+				ji := jScoreItem{Turn: item.Turn, Category: item.Category.String(), Points: item.Points}
+				if item.AreaID != NoArea {
+					ji.Area = item.AreaID.Name()
+				}
+				l.Items = append(l.Items, ji)
+			}
+			ledgers = append(ledgers, l)
+		}
+		c.JSON(http.StatusOK, gin.H{"players": ledgers})
+	}
+}
@@ -0,0 +1,66 @@
+package atf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoreLedgerByCategory(t *testing.T) {
+	tests := []struct {
+		name   string
+		ledger scoreLedger
+		want   map[scoreCategory]int
+	}{
+		{"empty", nil, map[scoreCategory]int{}},
+		{
+			"one category",
+			scoreLedger{{Turn: 1, Category: empireScore, AreaID: Ur, Points: 3}, {Turn: 2, Category: empireScore, AreaID: Uruk, Points: 2}},
+			map[scoreCategory]int{empireScore: 5},
+		},
+		{
+			"categories",
+			scoreLedger{
+				{Turn: 1, Category: empireScore, AreaID: Ur, Points: 3},
+				{Turn: 1, Category: cityExpansionScore, AreaID: Ur, Points: 1},
+				{Turn: 5, Category: workerMajorityScore, AreaID: Irrigation, Points: 2},
+				{Turn: 5, Category: adjustedScore, AreaID: NoArea, Points: -1},
+			},
+			map[scoreCategory]int{empireScore: 3, cityExpansionScore: 1, workerMajorityScore: 2, adjustedScore: -1},
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.ledger.byCategory(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: byCategory() = %v; want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestOpenLedger(t *testing.T) {
+	tests := []struct {
+		name   string
+		score  int
+		ledger scoreLedger
+		want   scoreLedger
+	}{
+		{"no score", 0, nil, nil},
+		{"score without ledger", 7, nil, scoreLedger{{Turn: 3, Category: adjustedScore, AreaID: NoArea, Points: 7}}},
+		{
+			"score with ledger",
+			7,
+			scoreLedger{{Turn: 2, Category: empireScore, AreaID: Ur, Points: 7}},
+			scoreLedger{{Turn: 2, Category: empireScore, AreaID: Ur, Points: 7}},
+		},
+	}
+
+	for _, test := range tests {
+		g := newTestGame()
+		g.Turn = 3
+		p := g.Players()[0]
+		p.Score, p.Ledger = test.score, test.ledger
+		p.openLedger()
+		if !reflect.DeepEqual(p.Ledger, test.want) {
+			t.Errorf("%s: Ledger = %v; want %v", test.name, p.Ledger, test.want)
+		}
+	}
+}
@@ -23,6 +23,10 @@ func (p *Player) newEntry() *Entry {
 	return e
 }
 
+func (e *Entry) playerID() int {
+	return e.PlayerID
+}
+
 func (e *Entry) PhaseName() string {
 	return fmt.Sprintf("Turn %d | Phase: %s | Round %d", e.Turn(), PhaseNames[e.Phase()], e.Round())
 }
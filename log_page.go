@@ -0,0 +1,333 @@
+package atf
+
+import (
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/SlothNinja/codec"
+	"github.com/SlothNinja/game"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	logPageKind     = "LogPage"
+	logPageSize     = 50
+	defaultLogLimit = 20
+)
+
+// logPage persists a fixed size run of game log entries as a child of the game entity.
+// Pages are append-only: only the last page of a game is ever rewritten.
+// PlayerIDs indexes the players with entries in the page, so the log of a player can be queried.
+type logPage struct {
+	Key        *datastore.Key `datastore:"__key__"`
+	Entries    game.GameLog   `datastore:"-"`
+	SavedState []byte         `datastore:",noindex"`
+	PlayerIDs  []int
+	UpdatedAt  time.Time
+}
+
+func (lp *logPage) Load(ps []datastore.Property) error {
+	err := datastore.LoadStruct(lp, ps)
+	if err != nil {
+		return err
+	}
+
+	var l game.GameLog
+	err = codec.Decode(&l, lp.SavedState)
+	if err != nil {
+		return err
+	}
+	lp.Entries = l
+	return nil
+}
+
+func (lp *logPage) Save() ([]datastore.Property, error) {
+	v, err := codec.Encode(lp.Entries)
+	if err != nil {
+		return nil, err
+	}
+	lp.SavedState = v
+	lp.PlayerIDs = nil
+	for _, e := range lp.Entries {
+		if pid := entryPlayerID(e); pid != NoPlayerID && !hasPlayerID(lp.PlayerIDs, pid) {
+			lp.PlayerIDs = append(lp.PlayerIDs, pid)
+		}
+	}
+	lp.UpdatedAt = time.Now()
+	return datastore.SaveStruct(lp)
+}
+
+func (lp *logPage) LoadKey(k *datastore.Key) error {
+	lp.Key = k
+	return nil
+}
+
+func newLogPage(g *Game, page int) *logPage {
+	return &logPage{Key: logPageKey(g, page)}
+}
+
+// page ids start at 1, as datastore does not permit an id of 0.
+func logPageKey(g *Game, page int) *datastore.Key {
+	return datastore.IDKey(logPageKind, int64(page+1), g.Key)
+}
+
+// logPagesFor returns the keys and pages needed to persist the pending entries of g.Log,
+// which start at log position base.  The last persisted page is read (within tx) when partially filled.
+func logPagesFor(tx *datastore.Transaction, g *Game, base int, pending game.GameLog) ([]*datastore.Key, []interface{}, error) {
+	if len(pending) == 0 {
+		return nil, nil, nil
+	}
+
+	var (
+		ks []*datastore.Key
+		es []interface{}
+	)
+
+	page := base / logPageSize
+	lp := newLogPage(g, page)
+	if base%logPageSize != 0 {
+		err := tx.Get(lp.Key, lp)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, e := range pending {
+		if len(lp.Entries) == logPageSize {
+			ks, es = append(ks, lp.Key), append(es, lp)
+			page += 1
+			lp = newLogPage(g, page)
+		}
+		lp.Entries = append(lp.Entries, e)
+	}
+	ks, es = append(ks, lp.Key), append(es, lp)
+	return ks, es, nil
+}
+
+// getLog returns up to limit persisted log entries starting at log position offset.
+func (client *Client) getLog(c *gin.Context, g *Game, offset, limit int) (game.GameLog, error) {
+	client.Log.Debugf(msgEnter)
+	defer client.Log.Debugf(msgExit)
+
+	if offset < 0 {
+		offset = 0
+	}
+	end := offset + limit
+	if end > g.LogLength {
+		end = g.LogLength
+	}
+	if offset >= end {
+		return nil, nil
+	}
+
+	first, last := offset/logPageSize, (end-1)/logPageSize
+	ks := make([]*datastore.Key, 0, last-first+1)
+	lps := make([]*logPage, 0, last-first+1)
+	for page := first; page <= last; page++ {
+		lp := newLogPage(g, page)
+		ks, lps = append(ks, lp.Key), append(lps, lp)
+	}
+
+	err := client.DS.GetMulti(c, ks, lps)
+	if err != nil {
+		return nil, err
+	}
+
+	var l game.GameLog
+	for _, lp := range lps {
+		l = append(l, lp.Entries...)
+	}
+	start := offset - first*logPageSize
+	l = l[start : start+end-offset]
+	for _, e := range l {
+		e.Init(g)
+	}
+	return l, nil
+}
+
+// entryPlayerID returns the id of the player for whom e was logged, or NoPlayerID.
+func entryPlayerID(e game.Entryer) int {
+	if pe, ok := e.(interface{ playerID() int }); ok {
+		return pe.playerID()
+	}
+	return NoPlayerID
+}
+
+func hasPlayerID(pids []int, pid int) bool {
+	for _, id := range pids {
+		if id == pid {
+			return true
+		}
+	}
+	return false
+}
+
+// playerLog returns the last limit entries logged for the player with the provided id,
+// including entries not yet persisted.  Only the pages indexed for the player are read.
+func (client *Client) playerLog(c *gin.Context, g *Game, pid, limit int) ([]jEntry, error) {
+	client.Log.Debugf(msgEnter)
+	defer client.Log.Debugf(msgExit)
+
+	var jes []jEntry
+	for i := len(g.Log) - 1; i >= 0 && len(jes) < limit; i-- {
+		if entryPlayerID(g.Log[i]) == pid {
+			jes = append(jes, toJEntry(g.Log[i], g.LogLength+i))
+		}
+	}
+
+	if len(jes) < limit {
+		q := datastore.NewQuery(logPageKind).
+			Ancestor(g.Key).
+			Filter("PlayerIDs =", pid).
+			KeysOnly()
+
+		ks, err := client.DS.GetAll(c, q, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// pages are read newest first, until enough entries are found
+		for j := len(ks) - 1; j >= 0 && len(jes) < limit; j-- {
+			lp := &logPage{}
+			err = client.DS.Get(c, ks[j], lp)
+			if err != nil {
+				return nil, err
+			}
+
+			page := int(ks[j].ID - 1)
+			for i := len(lp.Entries) - 1; i >= 0 && len(jes) < limit; i-- {
+				if e := lp.Entries[i]; entryPlayerID(e) == pid {
+					e.Init(g)
+					jes = append(jes, toJEntry(e, page*logPageSize+i))
+				}
+			}
+		}
+	}
+
+	// entries were gathered newest first
+	for i, j := 0, len(jes)-1; i < j; i, j = i+1, j-1 {
+		jes[i], jes[j] = jes[j], jes[i]
+	}
+	return jes, nil
+}
+
+// recentLog returns the last limit entries of the game log, including entries not yet persisted.
+func (client *Client) recentLog(c *gin.Context, g *Game, limit int) (game.GameLog, error) {
+	pending := len(g.Log)
+	if pending >= limit {
+		return g.Log[pending-limit:], nil
+	}
+
+	n := limit - pending
+	l, err := client.getLog(c, g, g.LogLength-n, n)
+	if err != nil {
+		return nil, err
+	}
+	return append(l, g.Log...), nil
+}
+
+type jEntry struct {
+	Position  int           `json:"position"`
+	PhaseName string        `json:"phaseName"`
+	HTML      template.HTML `json:"html"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+func toJEntry(e game.Entryer, position int) jEntry {
+	return jEntry{
+		Position:  position,
+		PhaseName: e.PhaseName(),
+		HTML:      e.HTML(),
+		CreatedAt: e.CreatedAt(),
+	}
+}
+
+func toJEntries(l game.GameLog, position int) []jEntry {
+	jes := make([]jEntry, len(l))
+	for i, e := range l {
+		jes[i] = toJEntry(e, position+i)
+	}
+	return jes
+}
+
+// gameLog provides paginated access to the game log.
+// Without an offset parameter, the most recent entries are returned.
+// With a player parameter, the most recent entries logged for that player are returned.
+func (client *Client) gameLog(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLogLimit)))
+		if err != nil || limit < 1 || limit > logPageSize {
+			limit = defaultLogLimit
+		}
+
+		if sid := c.Query("player"); sid != "" {
+			pid, err := strconv.Atoi(sid)
+			if err != nil || g.PlayerByID(pid) == nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid player"})
+				return
+			}
+
+			jes, err := client.playerLog(c, g, pid, limit)
+			if err != nil {
+				client.Log.Errorf(err.Error())
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{
+				"player":  pid,
+				"entries": jes,
+			})
+			return
+		}
+
+		total := g.LogLength + len(g.Log)
+		offset, err := strconv.Atoi(c.Query("offset"))
+		if err != nil {
+			offset = total - limit
+		}
+		if offset < 0 {
+			offset = 0
+		}
+
+		l, err := client.getLog(c, g, offset, limit)
+		if err != nil {
+			client.Log.Errorf(err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		// include pending entries that fall within the requested window
+		if end := offset + limit; end > g.LogLength {
+			start := offset - g.LogLength
+			if start < 0 {
+				start = 0
+			}
+			stop := end - g.LogLength
+			if stop > len(g.Log) {
+				stop = len(g.Log)
+			}
+			if start < stop {
+				l = append(l, g.Log[start:stop]...)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"total":   total,
+			"offset":  offset,
+			"entries": toJEntries(l, offset),
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package atf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLogPagePlayerIDs(t *testing.T) {
+	g := newTestGame()
+	ps := g.Players()
+
+	tests := []struct {
+		name string
+		log  func()
+		want []int
+	}{
+		{"no entries", func() {}, nil},
+		{"game entry", func() { g.newPrivilegesEntry() }, nil},
+		{"player entry", func() { ps[1].newAutoVPPassEntry() }, []int{1}},
+		{"repeated player", func() {
+			ps[2].newAutoVPPassEntry()
+			ps[0].newAutoVPPassEntry()
+			ps[2].newAutoVPPassEntry()
+		}, []int{2, 0}},
+	}
+
+	for _, test := range tests {
+		g.Log = nil
+		test.log()
+
+		lp := &logPage{Entries: g.Log}
+		_, err := lp.Save()
+		if err != nil {
+			t.Fatalf("%s: Save() error %v", test.name, err)
+		}
+		if !reflect.DeepEqual(lp.PlayerIDs, test.want) {
+			t.Errorf("%s: PlayerIDs = %v, want %v", test.name, lp.PlayerIDs, test.want)
+		}
+	}
+}
@@ -0,0 +1,373 @@
+package atf
+
+import (
+	"net/http"
+
+	"github.com/SlothNinja/game"
+	"github.com/SlothNinja/sn"
+	"github.com/SlothNinja/user"
+	"github.com/gin-gonic/gin"
+)
+
+// MultiActionID identifies the step reached by the current player within a multi-step action.
+type MultiActionID int
+
+const (
+	noMultiAction MultiActionID = iota
+	startedEmpireMA
+	boughtArmiesMA
+	equippedArmyMA
+	placedArmiesMA
+	usedScribeMA
+	selectedWorkerMA
+	placedWorkerMA
+	tradedResourceMA
+	expandEmpireMA
+	builtCityMA
+
+	// placedWorkersMA is not stored in g.MultiAction.
+	// It is the state of a turn in which the current player placed workers, as reported by g.multiAction.
+	placedWorkersMA
+)
+
+var multiActionNames = map[MultiActionID]string{
+	noMultiAction:    "none",
+	startedEmpireMA:  "started empire",
+	boughtArmiesMA:   "bought armies",
+	equippedArmyMA:   "equipped army",
+	placedArmiesMA:   "placed armies",
+	usedScribeMA:     "used scribe",
+	selectedWorkerMA: "selected worker",
+	placedWorkerMA:   "placed worker",
+	tradedResourceMA: "traded resource",
+	expandEmpireMA:   "expand empire",
+	builtCityMA:      "built city",
+	placedWorkersMA:  "placed workers",
+}
+
+func (ma MultiActionID) String() string {
+	return multiActionNames[ma]
+}
+
+// maTransition declares when an action may be performed and the states it may lead to.
+type maTransition struct {
+	Action string
+	// Verb completes sentences such as "You can not ... at this time."
+	Verb string
+	// From lists the states in which the action may be performed.
+	From []MultiActionID
+	// Continues lists the states in which the action continues an action already performed this turn.
+	// In all other states, the action requires that the current player has not yet performed an action.
+	Continues []MultiActionID
+	// To lists the states that may result from the action.
+	To []MultiActionID
+	// PaysCost reports whether the action requires payment of the action cost after another player passed.
+	PaysCost bool
+}
+
+var expandEmpireFrom = []MultiActionID{noMultiAction, expandEmpireMA}
+
+// maTransitions is the table of permitted transitions between multi-action states.
+// Phase and board conditions are checked by the validator of each action.
+var maTransitions = []maTransition{
+	{Action: "pay-action-cost", Verb: "pay action cost", From: []MultiActionID{noMultiAction}, To: []MultiActionID{noMultiAction}},
+	{Action: "pass", Verb: "pass", From: []MultiActionID{noMultiAction}, To: []MultiActionID{noMultiAction}},
+	{
+		Action:   "build-city",
+		Verb:     "build a city",
+		From:     []MultiActionID{noMultiAction},
+		To:       []MultiActionID{noMultiAction, builtCityMA},
+		PaysCost: true,
+	},
+	{Action: "abandon-city", Verb: "abandon a city", From: []MultiActionID{builtCityMA}, To: []MultiActionID{noMultiAction}},
+	{
+		Action:    "place-workers",
+		Verb:      "place workers",
+		From:      []MultiActionID{noMultiAction, placedWorkerMA},
+		Continues: []MultiActionID{placedWorkerMA},
+		To:        []MultiActionID{placedWorkersMA},
+		PaysCost:  true,
+	},
+	{
+		Action:    "use-scribe",
+		Verb:      "use a scribe",
+		From:      []MultiActionID{noMultiAction, placedWorkerMA, placedWorkersMA},
+		Continues: []MultiActionID{placedWorkerMA, placedWorkersMA},
+		To:        []MultiActionID{usedScribeMA},
+		PaysCost:  true,
+	},
+	{
+		Action:    "select-worker",
+		Verb:      "select a worker",
+		From:      []MultiActionID{usedScribeMA},
+		Continues: []MultiActionID{usedScribeMA},
+		To:        []MultiActionID{selectedWorkerMA},
+	},
+	{
+		Action:    "from-stock",
+		Verb:      "select a worker from stock",
+		From:      []MultiActionID{usedScribeMA},
+		Continues: []MultiActionID{usedScribeMA},
+		To:        []MultiActionID{selectedWorkerMA},
+	},
+	{
+		Action:    "place-worker",
+		Verb:      "place a worker",
+		From:      []MultiActionID{selectedWorkerMA},
+		Continues: []MultiActionID{selectedWorkerMA},
+		To:        []MultiActionID{placedWorkerMA},
+	},
+	{
+		Action:    "to-stock",
+		Verb:      "return a worker to stock",
+		From:      []MultiActionID{selectedWorkerMA},
+		Continues: []MultiActionID{selectedWorkerMA},
+		To:        []MultiActionID{placedWorkerMA},
+	},
+	{
+		Action:    "trade-resource",
+		Verb:      "trade",
+		From:      []MultiActionID{noMultiAction, tradedResourceMA},
+		Continues: []MultiActionID{tradedResourceMA},
+		To:        []MultiActionID{tradedResourceMA},
+		PaysCost:  true,
+	},
+	{
+		Action:    "make-tool",
+		Verb:      "make a tool",
+		From:      []MultiActionID{noMultiAction, tradedResourceMA},
+		Continues: []MultiActionID{tradedResourceMA},
+		To:        []MultiActionID{tradedResourceMA},
+		PaysCost:  true,
+	},
+	{
+		Action:   "start-empire",
+		Verb:     "start an empire",
+		From:     []MultiActionID{noMultiAction},
+		To:       []MultiActionID{startedEmpireMA},
+		PaysCost: true,
+	},
+	{Action: "buy-armies", Verb: "buy armies", From: []MultiActionID{startedEmpireMA}, To: []MultiActionID{boughtArmiesMA}},
+	{Action: "equip-army", Verb: "equip your army", From: []MultiActionID{boughtArmiesMA}, To: []MultiActionID{equippedArmyMA}},
+	{
+		Action: "confirm-start-empire",
+		Verb:   "start an empire in an occupied area",
+		From:   []MultiActionID{equippedArmyMA},
+		To:     []MultiActionID{equippedArmyMA},
+	},
+	{
+		Action:   "place-armies",
+		Verb:     "place armies",
+		From:     []MultiActionID{equippedArmyMA},
+		To:       []MultiActionID{placedArmiesMA},
+		PaysCost: true,
+	},
+	{
+		Action: "cancel-start-empire",
+		Verb:   "cancel the start of an empire",
+		From:   []MultiActionID{startedEmpireMA, boughtArmiesMA, equippedArmyMA},
+		To:     []MultiActionID{noMultiAction},
+	},
+	{
+		Action:    "reinforce-army",
+		Verb:      "reinforce an army",
+		From:      expandEmpireFrom,
+		Continues: []MultiActionID{expandEmpireMA},
+		To:        []MultiActionID{expandEmpireMA},
+		PaysCost:  true,
+	},
+	{
+		Action:    "invade-area",
+		Verb:      "invade an area",
+		From:      expandEmpireFrom,
+		Continues: []MultiActionID{expandEmpireMA},
+		To:        []MultiActionID{expandEmpireMA},
+		PaysCost:  true,
+	},
+	{
+		Action:    "invade-area-warning",
+		Verb:      "invade an area",
+		From:      expandEmpireFrom,
+		Continues: []MultiActionID{expandEmpireMA},
+		To:        expandEmpireFrom,
+		PaysCost:  true,
+	},
+	{
+		Action:    "confirm-invasion",
+		Verb:      "invade an area",
+		From:      expandEmpireFrom,
+		Continues: []MultiActionID{expandEmpireMA},
+		To:        []MultiActionID{expandEmpireMA},
+		PaysCost:  true,
+	},
+	{
+		Action:    "cancel-invasion",
+		Verb:      "cancel an invasion",
+		From:      expandEmpireFrom,
+		Continues: []MultiActionID{expandEmpireMA},
+		To:        expandEmpireFrom,
+	},
+	{
+		Action:    "destroy-city",
+		Verb:      "destroy a city",
+		From:      expandEmpireFrom,
+		Continues: []MultiActionID{expandEmpireMA},
+		To:        []MultiActionID{expandEmpireMA},
+		PaysCost:  true,
+	},
+	{Action: "expand-city", Verb: "expand a city", From: []MultiActionID{noMultiAction}, To: []MultiActionID{noMultiAction}},
+}
+
+func includesMA(mas []MultiActionID, ma MultiActionID) bool {
+	for _, ma2 := range mas {
+		if ma2 == ma {
+			return true
+		}
+	}
+	return false
+}
+
+func transitionFor(action string) (maTransition, bool) {
+	for _, t := range maTransitions {
+		if t.Action == action {
+			return t, true
+		}
+	}
+	return maTransition{}, false
+}
+
+// multiAction returns the multi-action state of the current turn.
+func (g *Game) multiAction() MultiActionID {
+	if g.MultiAction == noMultiAction && g.PlacedWorkers {
+		return placedWorkersMA
+	}
+	return g.MultiAction
+}
+
+// canPerform returns an error explaining why the multi-action state of the turn
+// does not permit p to perform action, or nil if it does.
+func (p *Player) canPerform(action string) error {
+	g := p.Game()
+	t, ok := transitionFor(action)
+	if !ok {
+		return sn.NewVError("%v is not a valid action.", action)
+	}
+
+	ma := g.multiAction()
+	switch {
+	case !includesMA(t.From, ma) && ma == noMultiAction:
+		return sn.NewVError("You can not %s at this time.", t.Verb)
+	case !includesMA(t.From, ma):
+		return sn.NewVError("You can not %s while performing a %q action.", t.Verb, ma)
+	case p.PerformedAction && !includesMA(t.Continues, ma):
+		return sn.NewVError("You have already performed an action.")
+	case t.PaysCost && g.anyPassed() && !p.PaidActionCost:
+		return sn.NewVError("After other players pass, you must pay action cost to %s.", t.Verb)
+	default:
+		return nil
+	}
+}
+
+// canAct returns an error explaining why p can not currently perform action during phase, or nil if p can.
+func (p *Player) canAct(action string, phase game.Phase) error {
+	g := p.Game()
+	verb := action
+	if t, ok := transitionFor(action); ok {
+		verb = t.Verb
+	}
+
+	switch {
+	case g.Phase != phase:
+		return sn.NewVError("You can not %s during the %s phase.", verb, g.PhaseName())
+	case !p.IsCurrentPlayer():
+		return sn.NewVError("Only the current player can %s.", verb)
+	case p.Passed:
+		return sn.NewVError("You can not %s after passing.", verb)
+	default:
+		return p.canPerform(action)
+	}
+}
+
+// validateMultiAction validates that cu is the current player and that the multi-action state permits action.
+func (g *Game) validateMultiAction(cu *user.User, action string) error {
+	if err := g.validatePlayerAction(cu); err != nil {
+		return err
+	}
+	return g.CurrentPlayer().canPerform(action)
+}
+
+// nextActions returns the actions that may be performed in the multi-action state ma.
+func nextActions(ma MultiActionID) []string {
+	var actions []string
+	for _, t := range maTransitions {
+		if includesMA(t.From, ma) {
+			actions = append(actions, t.Action)
+		}
+	}
+	return actions
+}
+
+// actionsAfter returns the actions that may follow action.
+func actionsAfter(action string) []string {
+	t, ok := transitionFor(action)
+	if !ok {
+		return nil
+	}
+
+	var actions []string
+	for _, ma := range t.To {
+		for _, a := range nextActions(ma) {
+			if !sslice(actions).include(a) {
+				actions = append(actions, a)
+			}
+		}
+	}
+	return actions
+}
+
+// nextActionsFor returns the actions p may perform in the current multi-action state.
+func (p *Player) nextActionsFor() []string {
+	var actions []string
+	for _, a := range nextActions(p.Game().multiAction()) {
+		if p.canPerform(a) == nil {
+			actions = append(actions, a)
+		}
+	}
+	return actions
+}
+
+// multiActions reports the multi-action state of a game and the actions that may follow it.
+// With an after parameter, the actions that may follow the named action are reported instead.
+func (client *Client) multiActions(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		if after := c.Query("after"); after != "" {
+			if _, ok := transitionFor(after); !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": after + " is not a valid action"})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"after": after, "next": actionsAfter(after)})
+			return
+		}
+
+		var available []string
+		if cp := g.CurrentPlayer(); cp != nil && !cp.Passed {
+			available = cp.nextActionsFor()
+		}
+
+		ma := g.multiAction()
+		c.JSON(http.StatusOK, gin.H{
+			"state":     ma.String(),
+			"next":      nextActions(ma),
+			"available": available,
+		})
+	}
+}
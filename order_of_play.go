@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"sort"
 
+	"github.com/SlothNinja/game"
 	"github.com/SlothNinja/log"
 	"github.com/SlothNinja/restful"
 	"github.com/gin-gonic/gin"
@@ -27,7 +28,7 @@ func (g *Game) orderOfPlay(c *gin.Context) {
 
 	ps := g.Players()
 	b := make([]Resources, g.NumPlayers)
-	sort.Sort(Reverse{ByBid{ps}})
+	ties := sortByBid(ps)
 	g.setPlayers(ps)
 	cp := g.Players()[0]
 	g.setCurrentPlayers(cp)
@@ -38,7 +39,35 @@ func (g *Game) orderOfPlay(c *gin.Context) {
 		n[i] = pid
 		b[pid] = p.PassedResources
 	}
-	g.newOrderOfPlayEntry(cnt, n, b)
+	g.newOrderOfPlayEntry(cnt, n, b, ties)
+	g.emit(nil, &TurnOrderDecided{Previous: cnt, Order: n})
+}
+
+// sortByBid sorts ps from highest to lowest bid.
+// Tied players retain their relative positions in the previous turn order,
+// so ps must be in the previous turn order.
+// Returns the player ids of each group of tied players in their new order.
+func sortByBid(ps Players) [][]int {
+	sort.SliceStable(ps, func(i, j int) bool {
+		return ps[i].compareByBid(ps[j]) == game.GreaterThan
+	})
+
+	var ties [][]int
+	for i := 0; i < len(ps); {
+		j := i + 1
+		for j < len(ps) && ps[j].compareByBid(ps[i]) == game.EqualTo {
+			j++
+		}
+		if j-i > 1 {
+			tie := make([]int, 0, j-i)
+			for _, p := range ps[i:j] {
+				tie = append(tie, p.ID())
+			}
+			ties = append(ties, tie)
+		}
+		i = j
+	}
+	return ties
 }
 
 type orderOfPlayEntry struct {
@@ -46,14 +75,16 @@ type orderOfPlayEntry struct {
 	Current []int
 	New     []int
 	Bids    []Resources
+	Ties    [][]int
 }
 
-func (g *Game) newOrderOfPlayEntry(c, n []int, b []Resources) {
+func (g *Game) newOrderOfPlayEntry(c, n []int, b []Resources, ties [][]int) {
 	e := &orderOfPlayEntry{
 		Entry:   g.newEntry(),
 		Current: c,
 		New:     n,
 		Bids:    b,
+		Ties:    ties,
 	}
 	g.Log = append(g.Log, e)
 }
@@ -68,6 +99,14 @@ func (e *orderOfPlayEntry) HTML() template.HTML {
 	for i, bid := range e.Bids {
 		s += restful.HTML("<div>%s placed a turn order bid of %d.</div>", g.NameByPID(i), bid.Value())
 	}
+	for _, tie := range e.Ties {
+		tied := make([]string, len(tie))
+		for i, pid := range tie {
+			tied[i] = g.NameByPID(pid)
+		}
+		s += restful.HTML("<div>&nbsp;</div><div>%s tied with bids of %d. The tie was broken by the current turn order, placing %s first.</div>",
+			restful.ToSentence(tied), e.Bids[tie[0]].Value(), tied[0])
+	}
 	for i, pid := range e.New {
 		names[i] = g.NameByPID(pid)
 	}
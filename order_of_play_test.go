@@ -0,0 +1,54 @@
+package atf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortByBid(t *testing.T) {
+	tests := []struct {
+		name string
+		// previous lists the player ids in the previous turn order
+		previous []int
+		// bids maps player ids to the value of their bids
+		bids      map[int]int
+		wantOrder []int
+		wantTies  [][]int
+	}{
+		{"untied", []int{0, 1, 2}, map[int]int{0: 1, 1: 3, 2: 2}, []int{1, 2, 0}, nil},
+		{"all tied", []int{2, 0, 1}, map[int]int{0: 2, 1: 2, 2: 2}, []int{2, 0, 1}, [][]int{{2, 0, 1}}},
+		{"no bids", []int{1, 2, 0}, map[int]int{}, []int{1, 2, 0}, [][]int{{1, 2, 0}}},
+		{"tie for last", []int{0, 1, 2}, map[int]int{0: 1, 1: 3, 2: 1}, []int{1, 0, 2}, [][]int{{0, 2}}},
+		{"tie for first", []int{2, 1, 0}, map[int]int{0: 4, 1: 2, 2: 4}, []int{2, 0, 1}, [][]int{{2, 0}}},
+		{
+			"two ties",
+			[]int{4, 3, 2, 1, 0},
+			map[int]int{0: 5, 1: 2, 2: 5, 3: 2, 4: 1},
+			[]int{2, 0, 3, 1, 4},
+			[][]int{{2, 0}, {3, 1}},
+		},
+	}
+
+	for _, test := range tests {
+		ps := make(Players, len(test.previous))
+		for i, pid := range test.previous {
+			p := newPlayer()
+			p.SetID(pid)
+			p.PassedResources = Resources{Grain: test.bids[pid], Lapis: 0}
+			ps[i] = p
+		}
+
+		ties := sortByBid(ps)
+
+		order := make([]int, len(ps))
+		for i, p := range ps {
+			order[i] = p.ID()
+		}
+		if !reflect.DeepEqual(order, test.wantOrder) {
+			t.Errorf("%s: order = %v; want %v", test.name, order, test.wantOrder)
+		}
+		if !reflect.DeepEqual(ties, test.wantTies) {
+			t.Errorf("%s: ties = %v; want %v", test.name, ties, test.wantTies)
+		}
+	}
+}
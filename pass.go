@@ -17,16 +17,12 @@ func init() {
 	gob.Register(new(passEntry))
 }
 
-func (g *Game) pass(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) pass(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validatePass(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
 	cp := g.CurrentPlayer()
+	cp.PassedResources = args.Resources
 	cp.Passed = true
 	cp.PerformedAction = true
 
@@ -38,28 +34,18 @@ func (g *Game) pass(c *gin.Context, cu *user.User) (tmpl string, act game.Action
 	e := cp.newPassEntry(cp.PassedResources)
 	restful.AddNoticef(c, string(e.HTML()))
 
-	tmpl, act = "atf/pass_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validatePass(c *gin.Context, cu *user.User) (err error) {
-	if err = g.validatePlayerAction(cu); err != nil {
+func (g *Game) validatePass(cu *user.User, args *actionArgs) (err error) {
+	if err = g.validateMultiAction(cu, "pass"); err != nil {
 		return
 	}
 
 	cp := g.CurrentPlayer()
-	if cp.PerformedAction {
-		err = sn.NewVError("You have already performed an action.")
-		return
-	}
-
-	if cp.PassedResources, err = getResourcesFrom(c); err != nil {
-		return
-	}
-
-	for r, cnt := range cp.PassedResources {
+	for r, cnt := range args.Resources {
 		if cnt > cp.Resources[r] {
-			cp.PassedResources = make(Resources, 8)
 			err = sn.NewVError("You do not have %d %s.", cnt, r)
 			return
 		}
@@ -79,7 +65,6 @@ func (p *Player) newPassEntry(resources Resources) *passEntry {
 		Entry:     p.newEntry(),
 		Resources: resources,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -3,7 +3,6 @@ package atf
 import (
 	"encoding/gob"
 	"html/template"
-	"strconv"
 
 	"github.com/SlothNinja/game"
 	"github.com/SlothNinja/log"
@@ -17,17 +16,11 @@ func init() {
 	gob.Register(new(payActionCostEntry))
 }
 
-func (g *Game) payActionCost(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) payActionCost(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var r Resource
-
-	if r, err = g.validatePayActionCost(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
+	r := args.Resource
 	cp := g.CurrentPlayer()
 	switch r {
 	case Army:
@@ -47,22 +40,16 @@ func (g *Game) payActionCost(c *gin.Context, cu *user.User) (tmpl string, act ga
 	e := cp.newPayActionCostEntry(r)
 	restful.AddNoticef(c, string(e.HTML()))
 
-	tmpl, act = "atf/paid_action_cost_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validatePayActionCost(c *gin.Context, cu *user.User) (r Resource, err error) {
-	if err = g.validatePlayerAction(cu); err != nil {
-		return
-	}
-
-	var rInt int
-
-	if rInt, err = strconv.Atoi(c.PostForm("Resource")); err != nil {
+func (g *Game) validatePayActionCost(cu *user.User, args *actionArgs) (err error) {
+	if err = g.validateMultiAction(cu, "pay-action-cost"); err != nil {
 		return
 	}
 
-	r = Resource(rInt)
+	r := args.Resource
 	cp := g.CurrentPlayer()
 
 	switch {
@@ -95,7 +82,6 @@ func (p *Player) newPayActionCostEntry(r Resource) *payActionCostEntry {
 		Entry:    p.newEntry(),
 		Resource: r,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -0,0 +1,148 @@
+package atf
+
+import (
+	"github.com/SlothNinja/log"
+	"github.com/gin-gonic/gin"
+)
+
+// Names of the phase pipelines run by the turn flow.
+const (
+	startTurnPipeline = "start-turn"
+	endOfTurnPipeline = "end-of-turn"
+)
+
+// phaseHandler is a named step of a phase pipeline.
+type phaseHandler struct {
+	Name string
+	Run  func(*Game, *gin.Context)
+}
+
+// phaseHook observes the step of pipeline named step.
+type phaseHook func(g *Game, c *gin.Context, pipeline, step string)
+
+// phasePipeline is an ordered list of phase handlers.
+type phasePipeline []phaseHandler
+
+// pipelineOption adapts a copy of a pipeline to the game it is run for, e.g., to implement a variant.
+type pipelineOption func(g *Game, name string, p phasePipeline) phasePipeline
+
+// Registries are populated during package initialization and are read-only afterwards.
+var (
+	phasePipelines = map[string]phasePipeline{
+		startTurnPipeline: {
+			{Name: "collect-grain", Run: (*Game).collectGrainPhase},
+			{Name: "collect-textile", Run: (*Game).collectTextilePhase},
+			{Name: "collect-workers", Run: (*Game).collectWorkersPhase},
+			{Name: "reset-scribes", Run: (*Game).resetScribesPhase},
+			{Name: "reset-toolmakers", Run: (*Game).resetToolMakersPhase},
+			{Name: "decline", Run: (*Game).declinePhase},
+			{Name: "actions", Run: (*Game).actionsPhase},
+		},
+		endOfTurnPipeline: {
+			{Name: "return-armies", Run: (*Game).returnArmies},
+			{Name: "return-workers", Run: (*Game).returnWorkers},
+			{Name: "reset-passboxes", Run: (*Game).resetPassboxes},
+			{Name: "reset-army-boxes", Run: (*Game).resetArmyBoxes},
+		},
+	}
+	pipelineOptions []pipelineOption
+	beforeHooks     []phaseHook
+	afterHooks      []phaseHook
+)
+
+// registerPipelineOption registers an option applied to every pipeline before it is run.
+// Options are applied in order of registration.
+func registerPipelineOption(opt pipelineOption) {
+	pipelineOptions = append(pipelineOptions, opt)
+}
+
+// beforePhase registers a hook run before each step of every pipeline.
+func beforePhase(hook phaseHook) {
+	beforeHooks = append(beforeHooks, hook)
+}
+
+// afterPhase registers a hook run after each step of every pipeline.
+func afterPhase(hook phaseHook) {
+	afterHooks = append(afterHooks, hook)
+}
+
+func (p phasePipeline) index(name string) int {
+	for i, h := range p {
+		if h.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertBefore returns a copy of p with h inserted before the step named name.
+// If no such step exists, h is appended.
+func (p phasePipeline) insertBefore(name string, h phaseHandler) phasePipeline {
+	i := p.index(name)
+	if i == -1 {
+		i = len(p)
+	}
+	return p.insertAt(i, h)
+}
+
+// insertAfter returns a copy of p with h inserted after the step named name.
+// If no such step exists, h is appended.
+func (p phasePipeline) insertAfter(name string, h phaseHandler) phasePipeline {
+	i := p.index(name)
+	if i == -1 {
+		i = len(p) - 1
+	}
+	return p.insertAt(i+1, h)
+}
+
+func (p phasePipeline) insertAt(i int, h phaseHandler) phasePipeline {
+	p2 := make(phasePipeline, 0, len(p)+1)
+	p2 = append(p2, p[:i]...)
+	p2 = append(p2, h)
+	return append(p2, p[i:]...)
+}
+
+// replace returns a copy of p in which the step named name runs run instead.
+func (p phasePipeline) replace(name string, run func(*Game, *gin.Context)) phasePipeline {
+	p2 := append(phasePipeline(nil), p...)
+	if i := p2.index(name); i != -1 {
+		p2[i].Run = run
+	}
+	return p2
+}
+
+// skip returns a copy of p without the step named name.
+func (p phasePipeline) skip(name string) phasePipeline {
+	p2 := make(phasePipeline, 0, len(p))
+	for _, h := range p {
+		if h.Name != name {
+			p2 = append(p2, h)
+		}
+	}
+	return p2
+}
+
+// pipeline returns the named pipeline as adapted for g by the registered options.
+func (g *Game) pipeline(name string) phasePipeline {
+	p := phasePipelines[name]
+	for _, opt := range pipelineOptions {
+		p = opt(g, name, p)
+	}
+	return p
+}
+
+// runPipeline runs the steps of the named pipeline, surrounding each with the registered hooks.
+func (g *Game) runPipeline(c *gin.Context, name string) {
+	log.Debugf(msgEnter)
+	defer log.Debugf(msgExit)
+
+	for _, h := range g.pipeline(name) {
+		for _, hook := range beforeHooks {
+			hook(g, c, name, h.Name)
+		}
+		h.Run(g, c)
+		for _, hook := range afterHooks {
+			hook(g, c, name, h.Name)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package atf
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stepNames returns the names of the steps of p.
+func stepNames(p phasePipeline) []string {
+	var names []string
+	for _, h := range p {
+		names = append(names, h.Name)
+	}
+	return names
+}
+
+// recordStep returns a step named name that appends run:name to runs.
+func recordStep(name string, runs *[]string) phaseHandler {
+	return phaseHandler{Name: name, Run: func(*Game, *gin.Context) { *runs = append(*runs, "run:"+name) }}
+}
+
+func TestPhasePipelineEdits(t *testing.T) {
+	p := phasePipeline{{Name: "collect"}, {Name: "decline"}, {Name: "actions"}}
+	extra := phaseHandler{Name: "extra"}
+
+	tests := []struct {
+		name string
+		edit func() phasePipeline
+		want []string
+	}{
+		{"insert before", func() phasePipeline { return p.insertBefore("decline", extra) },
+			[]string{"collect", "extra", "decline", "actions"}},
+		{"insert before first", func() phasePipeline { return p.insertBefore("collect", extra) },
+			[]string{"extra", "collect", "decline", "actions"}},
+		{"insert before missing", func() phasePipeline { return p.insertBefore("missing", extra) },
+			[]string{"collect", "decline", "actions", "extra"}},
+		{"insert after", func() phasePipeline { return p.insertAfter("decline", extra) },
+			[]string{"collect", "decline", "extra", "actions"}},
+		{"insert after last", func() phasePipeline { return p.insertAfter("actions", extra) },
+			[]string{"collect", "decline", "actions", "extra"}},
+		{"insert after missing", func() phasePipeline { return p.insertAfter("missing", extra) },
+			[]string{"collect", "decline", "actions", "extra"}},
+		{"skip", func() phasePipeline { return p.skip("decline") },
+			[]string{"collect", "actions"}},
+		{"skip missing", func() phasePipeline { return p.skip("missing") },
+			[]string{"collect", "decline", "actions"}},
+	}
+
+	for _, test := range tests {
+		if got := stepNames(test.edit()); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: steps = %v, want %v", test.name, got, test.want)
+		}
+		if got := stepNames(p); !reflect.DeepEqual(got, []string{"collect", "decline", "actions"}) {
+			t.Fatalf("%s: modified the original pipeline: %v", test.name, got)
+		}
+	}
+}
+
+func TestRunPipeline(t *testing.T) {
+	const name = "test-turn"
+
+	var runs []string
+	phasePipelines[name] = phasePipeline{
+		recordStep("collect", &runs),
+		recordStep("decline", &runs),
+		recordStep("actions", &runs),
+	}
+	savedOptions, savedBefore, savedAfter := pipelineOptions, beforeHooks, afterHooks
+	defer func() {
+		delete(phasePipelines, name)
+		pipelineOptions, beforeHooks, afterHooks = savedOptions, savedBefore, savedAfter
+	}()
+
+	tests := []struct {
+		name    string
+		options []pipelineOption
+		hooks   bool
+		want    []string
+	}{
+		{name: "registered steps", want: []string{"run:collect", "run:decline", "run:actions"}},
+		{
+			name: "insert",
+			options: []pipelineOption{func(g *Game, n string, p phasePipeline) phasePipeline {
+				return p.insertAfter("collect", recordStep("extra", &runs))
+			}},
+			want: []string{"run:collect", "run:extra", "run:decline", "run:actions"},
+		},
+		{
+			name: "replace",
+			options: []pipelineOption{func(g *Game, n string, p phasePipeline) phasePipeline {
+				return p.replace("decline", func(*Game, *gin.Context) { runs = append(runs, "run:variant-decline") })
+			}},
+			want: []string{"run:collect", "run:variant-decline", "run:actions"},
+		},
+		{
+			name: "skip",
+			options: []pipelineOption{func(g *Game, n string, p phasePipeline) phasePipeline {
+				return p.skip("decline")
+			}},
+			want: []string{"run:collect", "run:actions"},
+		},
+		{
+			name: "options of other pipelines",
+			options: []pipelineOption{func(g *Game, n string, p phasePipeline) phasePipeline {
+				if n != endOfTurnPipeline {
+					return p
+				}
+				return p.skip("decline")
+			}},
+			want: []string{"run:collect", "run:decline", "run:actions"},
+		},
+		{
+			name:  "hooks",
+			hooks: true,
+			options: []pipelineOption{func(g *Game, n string, p phasePipeline) phasePipeline {
+				return p.skip("collect").skip("decline")
+			}},
+			want: []string{"before:test-turn:actions", "run:actions", "after:test-turn:actions"},
+		},
+	}
+
+	for _, test := range tests {
+		runs = nil
+		pipelineOptions, beforeHooks, afterHooks = nil, nil, nil
+		for _, opt := range test.options {
+			registerPipelineOption(opt)
+		}
+		if test.hooks {
+			beforePhase(func(g *Game, c *gin.Context, pipeline, step string) {
+				runs = append(runs, "before:"+pipeline+":"+step)
+			})
+			afterPhase(func(g *Game, c *gin.Context, pipeline, step string) {
+				runs = append(runs, "after:"+pipeline+":"+step)
+			})
+		}
+
+		newTestGame().runPipeline(nil, name)
+		if !reflect.DeepEqual(runs, test.want) {
+			t.Errorf("%s: runs = %v, want %v", test.name, runs, test.want)
+		}
+	}
+}
@@ -17,16 +17,11 @@ func init() {
 	gob.Register(new(removeWorkersEntry))
 }
 
-func (g *Game) placeArmies(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) placeArmies(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var armies int
-	if armies, err = g.validatePlaceArmies(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
+	armies := args.Count
 	cp := g.CurrentPlayer()
 	cp.PerformedAction = true
 	cp.Army -= armies
@@ -49,19 +44,17 @@ func (g *Game) placeArmies(c *gin.Context, cu *user.User) (tmpl string, act game
 	e2 := cp.newPlaceArmiesEntry(armies, area)
 	restful.AddNoticef(c, string(e2.HTML()))
 
-	tmpl, act = "atf/place_armies_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validatePlaceArmies(c *gin.Context, cu *user.User) (armies int, err error) {
+func (g *Game) validatePlaceArmies(cu *user.User, args *actionArgs) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var cp *Player
-	switch cp, armies, err = g.CurrentPlayer(), getPlacedArmies(c), g.validatePlayerAction(cu); {
+	armies := args.Count
+	switch err = g.validateMultiAction(cu, "place-armies"); {
 	case err != nil:
-	case cp.PerformedAction:
-		err = sn.NewVError("You have already performed an action.")
 	case armies < 1 || armies > 2:
 		err = sn.NewVError("You can't place %d armies in %s.", armies, g.SelectedArea().Name())
 	}
@@ -81,7 +74,6 @@ func (p *Player) newPlaceArmiesEntry(armies int, area *Area) *placeArmiesEntry {
 		Armies:   armies,
 		AreaName: area.Name(),
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -106,7 +98,6 @@ func (p *Player) newRemoveWorkersEntry(workers int, area *Area) *removeWorkersEn
 		Workers:  workers,
 		AreaName: area.Name(),
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
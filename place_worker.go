@@ -36,12 +36,10 @@ func (g *Game) validateToStock(c *gin.Context, cu *user.User) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	switch err = g.validatePlayerAction(cu); {
+	switch err = g.validateMultiAction(cu, "to-stock"); {
 	case err != nil:
 	case g.From == "Stock":
 		err = sn.NewVError("The originating and destination areas for a moved worker cannot be the same.")
-	case g.MultiAction != selectedWorkerMA:
-		err = sn.NewVError("You cannot chose 'From Stock' at this time.")
 	}
 	return
 }
@@ -75,7 +73,7 @@ func (g *Game) validatePlaceWorker(c *gin.Context, cu *user.User) (err error) {
 		cp *Player
 	)
 
-	switch cp, a, err = g.CurrentPlayer(), g.SelectedArea(), g.validatePlayerAction(cu); {
+	switch cp, a, err = g.CurrentPlayer(), g.SelectedArea(), g.validateMultiAction(cu, "place-worker"); {
 	case err != nil:
 	case a == nil:
 		err = sn.NewVError("No area selected.")
@@ -16,16 +16,11 @@ func init() {
 	gob.Register(new(placeWorkersEntry))
 }
 
-func (g *Game) placeWorkers(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) placeWorkers(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	res, workers, err := g.validatePlaceWorkers(c, cu)
-	if err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
+	res, workers := args.Resource, args.Count
 	cp := g.CurrentPlayer()
 	cp.PerformedAction = true
 	cp.Resources[res] -= 1
@@ -41,17 +36,16 @@ func (g *Game) placeWorkers(c *gin.Context, cu *user.User) (tmpl string, act gam
 	// Log
 	e := cp.newPlaceWorkersEntry(res, workers)
 	restful.AddNoticef(c, string(e.HTML()))
-	tmpl, act = "atf/place_workers_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validatePlaceWorkers(c *gin.Context, cu *user.User) (rs Resource, ws int, err error) {
-	if err = g.validatePlayerAction(cu); err != nil {
+func (g *Game) validatePlaceWorkers(cu *user.User, args *actionArgs) (err error) {
+	if err = g.validateMultiAction(cu, "place-workers"); err != nil {
 		return
 	}
 
-	rs = getPaidResource(c)
-	ws = getPlaceWorkers(c)
+	rs, ws := args.Resource, args.Count
 	cp := g.CurrentPlayer()
 	a := g.SelectedArea()
 
@@ -88,7 +82,6 @@ func (p *Player) newPlaceWorkersEntry(res Resource, workers int) *placeWorkersEn
 		Resource: res,
 		Workers:  workers,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -0,0 +1,171 @@
+package atf
+
+import (
+	"encoding/gob"
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/SlothNinja/game"
+	"github.com/SlothNinja/restful"
+)
+
+func init() {
+	gob.Register(new(tieBreakEntry))
+}
+
+// tieBreaker is a criterion, applied in order, for placing players having equal scores.
+// A player with a higher value places ahead of a player with a lower value.
+type tieBreaker struct {
+	Name  string
+	Value func(*Player) int
+}
+
+// Tie-breakers are a house rule of this implementation, not a rule of the published game.
+// The tiebreak option of a game lists, separated by "-", the keys of the tie-breakers applied, in order,
+// e.g., "resources-cities" (the default) or "none", in which case players having equal scores share a place.
+var tieBreakers = map[string]tieBreaker{
+	"resources": {Name: "Resource Value", Value: func(p *Player) int { return p.Resources.Value() }},
+	"cities":    {Name: "Cities", Value: (*Player).citiesInPlay},
+}
+
+// tieBreakers returns the tie-breakers of the game, in the order applied.
+func (g *Game) tieBreakers() []tieBreaker {
+	var tbs []tieBreaker
+	for _, key := range strings.Split(g.variant()["tiebreak"], "-") {
+		if tb, ok := tieBreakers[key]; ok {
+			tbs = append(tbs, tb)
+		}
+	}
+	return tbs
+}
+
+// citiesInPlay returns the number of cities p has on the board.
+func (p *Player) citiesInPlay() int {
+	cnt := 0
+	for _, a := range p.Game().Areas {
+		if a.City != nil && a.City.Built {
+			if o := a.City.Owner(); o != nil && o.Equal(p) {
+				cnt++
+			}
+		}
+	}
+	return cnt
+}
+
+// compareByPlace compares p and p2 by score and then by the tie-breakers.
+func (p *Player) compareByPlace(p2 *Player) game.Comparison {
+	if c := p.compareByScore(p2); c != game.EqualTo {
+		return c
+	}
+	for _, tb := range p.Game().tieBreakers() {
+		switch v1, v2 := tb.Value(p), tb.Value(p2); {
+		case v1 < v2:
+			return game.LessThan
+		case v1 > v2:
+			return game.GreaterThan
+		}
+	}
+	return game.EqualTo
+}
+
+// placePlayers orders the players by place and sets the players in that order.
+// Returns the players grouped by place.  Players share a place only when the tie-breakers are exhausted.
+func (g *Game) placePlayers() []Players {
+	ps := g.Players()
+	sort.SliceStable(ps, func(i, j int) bool {
+		return ps[i].compareByPlace(ps[j]) == game.GreaterThan
+	})
+	g.setPlayers(ps)
+
+	var places []Players
+	for _, p := range ps {
+		if l := len(places); l > 0 && places[l-1][0].compareByPlace(p) == game.EqualTo {
+			places[l-1] = append(places[l-1], p)
+			continue
+		}
+		places = append(places, Players{p})
+	}
+	return places
+}
+
+type tieBreakRow struct {
+	PlayerID int
+	Score    int
+	Values   []int
+	Place    int
+}
+
+type tieBreakEntry struct {
+	*Entry
+	Criteria []string
+	Ties     [][]tieBreakRow
+}
+
+// newTieBreakEntry logs the tie-breaker values of each group of players having equal scores.
+// No entry is logged absent equal scores.
+func (g *Game) newTieBreakEntry(places []Players) {
+	var (
+		ties [][]tieBreakRow
+		tie  []tieBreakRow
+		tbs  = g.tieBreakers()
+	)
+	for i, place := range places {
+		for _, p := range place {
+			if len(tie) > 0 && g.PlayerByID(tie[0].PlayerID).compareByScore(p) != game.EqualTo {
+				if len(tie) > 1 {
+					ties = append(ties, tie)
+				}
+				tie = nil
+			}
+			row := tieBreakRow{PlayerID: p.ID(), Score: p.Score, Place: i + 1}
+			for _, tb := range tbs {
+				row.Values = append(row.Values, tb.Value(p))
+			}
+			tie = append(tie, row)
+		}
+	}
+	if len(tie) > 1 {
+		ties = append(ties, tie)
+	}
+
+	if len(ties) == 0 {
+		return
+	}
+
+	criteria := make([]string, len(tbs))
+	for i, tb := range tbs {
+		criteria[i] = tb.Name
+	}
+
+	e := &tieBreakEntry{
+		Entry:    g.newEntry(),
+		Criteria: criteria,
+		Ties:     ties,
+	}
+	g.Log = append(g.Log, e)
+}
+
+func (e *tieBreakEntry) HTML() template.HTML {
+	g := e.Game()
+	s := restful.HTML("<div>Players having equal scores share a place.</div>")
+	if len(e.Criteria) > 0 {
+		s = restful.HTML("<div>Ties are broken by %s.</div>", restful.ToSentence(e.Criteria))
+	}
+	for _, tie := range e.Ties {
+		s += restful.HTML("<div>&nbsp;</div><table class='strippedDataTable'><thead><tr><th>Player</th><th>Score</th>")
+		for _, name := range e.Criteria {
+			s += restful.HTML("<th>%s</th>", name)
+		}
+		s += restful.HTML("<th>Place</th></tr></thead><tbody>")
+		for _, row := range tie {
+			s += restful.HTML("<tr><td>%s</td><td>%d</td>", g.NameByPID(row.PlayerID), row.Score)
+			for _, v := range row.Values {
+				s += restful.HTML("<td>%d</td>", v)
+			}
+			s += restful.HTML("<td>%d</td></tr>", row.Place)
+		}
+		s += restful.HTML("</tbody></table>")
+	}
+	return s
+}
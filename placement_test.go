@@ -0,0 +1,49 @@
+package atf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlacePlayers(t *testing.T) {
+	tests := []struct {
+		name     string
+		tiebreak string
+		scores   []int
+		// resources sets the grain of each player
+		grain []int
+		// want lists the ids of the players in each place
+		want [][]int
+	}{
+		{"no ties", "", []int{5, 9, 7}, []int{0, 0, 0}, [][]int{{1}, {2}, {0}}},
+		{"resources break tie", "", []int{5, 5, 7}, []int{1, 3, 0}, [][]int{{2}, {1}, {0}}},
+		{"unbroken tie", "", []int{5, 5, 7}, []int{2, 2, 0}, [][]int{{2}, {0, 1}}},
+		{"no tie-breakers", "none", []int{5, 5, 7}, []int{1, 3, 0}, [][]int{{2}, {0, 1}}},
+	}
+
+	for _, test := range tests {
+		g := newTestGame()
+		if test.tiebreak != "" {
+			g.Options = []string{"tiebreak=" + test.tiebreak}
+		}
+		for i, p := range g.Players() {
+			p.Score = test.scores[i]
+			p.Resources = make(Resources, 8)
+			p.Resources[Grain] = test.grain[i]
+		}
+
+		places := g.placePlayers()
+		var got [][]int
+		for _, place := range places {
+			var ids []int
+			for _, p := range place {
+				ids = append(ids, p.ID())
+			}
+			got = append(got, ids)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: placePlayers() = %v; want %v", test.name, got, test.want)
+		}
+	}
+}
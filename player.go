@@ -24,7 +24,6 @@ func init() {
 
 type Player struct {
 	*game.Player
-	Log             game.GameLog
 	Resources       Resources `form:"resources"`
 	City            int       `form:"city"`
 	Expansion       int       `form:"expansion"`
@@ -36,6 +35,7 @@ type Player struct {
 	PaidActionCost  bool      `form:"paid-action-cost"`
 	UsedSippar      bool      `form:"used-sippar"`
 	VPPassed        bool      `form:"vp-passed"`
+	Ledger          scoreLedger
 }
 
 func (p *Player) Game() *Game {
@@ -71,7 +71,6 @@ type autoVPPassEntry struct {
 func (p *Player) newAutoVPPassEntry() *autoVPPassEntry {
 	g := p.Game()
 	e := &autoVPPassEntry{Entry: p.newEntry()}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -112,39 +111,42 @@ func (this *Player) compareByBid(p *Player) game.Comparison {
 }
 
 func (client *Client) determinePlaces(c *gin.Context, g *Game) ([]contest.ResultsMap, error) {
-	// sort players by score
-	players := g.Players()
-	sort.Sort(Reverse{ByScore{players}})
-	g.setPlayers(players)
+	// sort players by score and tie-breakers
+	placed := g.placePlayers()
+	placeOf := make(map[int]int, g.NumPlayers)
+	for i, place := range placed {
+		for _, p := range place {
+			placeOf[p.ID()] = i + 1
+		}
+	}
 
 	places := make([]contest.ResultsMap, 0)
-	for i, p1 := range g.Players() {
+	for _, place := range placed {
 		rmap := make(contest.ResultsMap, 0)
-		results := make([]*contest.Result, 0)
-		for j, p2 := range g.Players() {
-			r, err := client.Rating.For(c, p2.User(), g.Type)
-			if err != nil {
-				return nil, err
-			}
-			result := &contest.Result{
-				GameID: g.ID(),
-				Type:   g.Type,
-				R:      r.R,
-				RD:     r.RD,
-			}
-			switch c := p1.compareByScore(p2); {
-			case i == j:
-			case c == game.GreaterThan:
-				result.Outcome = 1
-				results = append(results, result)
-			case c == game.LessThan:
-				result.Outcome = 0
-				results = append(results, result)
-			case c == game.EqualTo:
-				result.Outcome = 0.5
+		for _, p1 := range place {
+			results := make([]*contest.Result, 0)
+			for _, p2 := range g.Players() {
+				if p1.Equal(p2) {
+					continue
+				}
+				outcome, rated := client.ratingModel.Outcome(placeOf[p1.ID()], placeOf[p2.ID()])
+				if !rated {
+					continue
+				}
+				r, err := client.Rating.For(c, p2.User(), g.Type)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, &contest.Result{
+					GameID:  g.ID(),
+					Type:    g.Type,
+					R:       r.R,
+					RD:      r.RD,
+					Outcome: outcome,
+				})
 			}
+			rmap[p1.User().Key] = results
 		}
-		rmap[p1.User().Key] = results
 		places = append(places, rmap)
 	}
 	return places, nil
@@ -166,15 +168,6 @@ var NotFound = errors.New("Not Found")
 
 func (p *Player) Init(gr game.Gamer) {
 	p.SetGame(gr)
-
-	g, ok := gr.(*Game)
-	if !ok {
-		return
-	}
-
-	for _, entry := range p.Log {
-		entry.Init(g)
-	}
 }
 
 func newPlayer() *Player {
@@ -201,6 +194,11 @@ func CreatePlayer(g *Game) *Player {
 	p.SetID(int(len(g.Players())))
 	p.SetGame(g)
 
+	v := g.variant()
+	p.Resources = v.startingResources()
+	p.Worker = v.workers()
+	p.WorkerSupply = totalWorkers - p.Worker
+
 	colorMap := g.DefaultColorMap()
 	p.SetColorMap(make(color.Colors, g.NumPlayers))
 
@@ -255,51 +253,75 @@ func (p *Player) CanUseSippar() bool {
 	if p == nil {
 		return false
 	}
-	return p.hasCityIn(Sippar) && !p.UsedSippar
+	_, ok := p.privilege(onTrade)
+	return ok
 }
 
 func (p *Player) CanPayActionCost(a *Area) bool {
 	if p == nil {
 		return false
 	}
+	return p.canPayActionCost() == nil
+}
+
+func (p *Player) canPayActionCost() error {
 	g := p.Game()
-	return g.Phase == Actions &&
-		g.MultiAction == noMultiAction &&
-		p.IsCurrentPlayer() &&
-		!p.PerformedAction &&
-		!p.Passed &&
-		g.anyPassed() &&
-		!p.PaidActionCost
+	switch err := p.canAct("pay-action-cost", Actions); {
+	case err != nil:
+		return err
+	case !g.anyPassed():
+		return sn.NewVError("You need not pay an action cost until another player passes.")
+	case p.PaidActionCost:
+		return sn.NewVError("You have already paid action cost.")
+	default:
+		return nil
+	}
 }
 
 func (p *Player) CanBuildCityIn(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	return g.Phase == Actions &&
-		g.MultiAction == noMultiAction &&
-		p.IsCurrentPlayer() &&
-		!p.PerformedAction &&
-		!p.Passed &&
-		((g.anyPassed() && p.PaidActionCost) || !g.anyPassed()) &&
-		a.IsSumer() &&
-		!a.City.Built
+	return p.canBuildCityIn(a) == nil
+}
+
+func (p *Player) canBuildCityIn(a *Area) error {
+	switch err := p.canAct("build-city", Actions); {
+	case err != nil:
+		return err
+	case a == nil:
+		return sn.NewVError("No area selected.")
+	case !a.IsSumer():
+		return sn.NewVError("%s is not a Sumer area.", a.Name())
+	case a.City.Built:
+		return sn.NewVError("The city in %s is already built.", a.Name())
+	default:
+		return nil
+	}
 }
 
 func (p *Player) CanAbandonCityIn(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	return g.Phase == Actions &&
-		g.MultiAction == builtCityMA &&
-		p.IsCurrentPlayer() &&
-		!p.PerformedAction &&
-		!p.Passed &&
-		a.IsSumer() &&
-		a.ID != g.BuiltCityAreaID &&
-		p.hasCityIn(a.ID)
+	return p.canAbandonCityIn(a) == nil
+}
+
+func (p *Player) canAbandonCityIn(a *Area) error {
+	switch err := p.canAct("abandon-city", Actions); {
+	case err != nil:
+		return err
+	case a == nil:
+		return sn.NewVError("No area selected.")
+	case !a.IsSumer():
+		return sn.NewVError("%s is not a Sumer area.", a.Name())
+	case a.ID == p.Game().BuiltCityAreaID:
+		return sn.NewVError("You can not abandon the city you just built.")
+	case !p.hasCityIn(a.ID):
+		return sn.NewVError("You do not have a city in %s.", a.Name())
+	default:
+		return nil
+	}
 }
 
 func (p *Player) CanPlaceWorkersIn(a *Area) bool {
@@ -322,25 +344,19 @@ func (p *Player) CanPlaceWorkersIn(a *Area) bool {
 
 func (p *Player) canPlaceWorkersIn(a *Area) error {
 	g := p.Game()
-	switch {
+	switch err := p.canPerform("place-workers"); {
 	case a == nil:
 		return sn.NewVError("No area selected.")
 	case g.Phase != Actions:
 		return sn.NewVError("You can not place workers during the %s phase.", g.PhaseName())
-	case p.PerformedAction && g.MultiAction != placedWorkerMA:
-		return sn.NewVError("You have already performed another action.")
-	case g.MultiAction != noMultiAction && g.MultiAction != placedWorkerMA:
-		return sn.NewVError("You have another action in progress.")
 	case !p.IsCurrentPlayer():
 		return sn.NewVError("Only the current player can place a worker.")
 	case p.Passed:
 		return sn.NewVError("You can not place workers after passing.")
-	case g.anyPassed() && !p.PaidActionCost:
-		return sn.NewVError("After other players pass, you must pay action cost to place workers.")
 	case g.PlacedWorkers:
 		return sn.NewVError("You have already placed workers")
-	case p.PerformedAction && g.MultiAction != placedWorkerMA:
-		return sn.NewVError("You have already performed an action.")
+	case err != nil:
+		return err
 	case a.IsSumer():
 		return sn.NewVError("You can't place workers in Sumer.")
 	case p.Worker <= 0:
@@ -358,14 +374,22 @@ func (p *Player) CanUseScribe(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	return g.Phase == Actions &&
-		(g.MultiAction == noMultiAction || g.MultiAction == placedWorkerMA) &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		((g.anyPassed() && p.PaidActionCost) || !g.anyPassed()) &&
-		a.ID == Scribes &&
-		p.WorkersIn(a) > 0
+	return p.canUseScribe(a) == nil
+}
+
+func (p *Player) canUseScribe(a *Area) error {
+	switch err := p.canAct("use-scribe", Actions); {
+	case err != nil:
+		return err
+	case a == nil:
+		return sn.NewVError("No area selected.")
+	case a.ID != Scribes:
+		return sn.NewVError("You must chose Scribes area in order to use scribe.")
+	case p.WorkersIn(a) < 1:
+		return sn.NewVError("You don't have a scribe to use.")
+	default:
+		return nil
+	}
 }
 
 func (p *Player) CanTradeIn(a *Area) bool {
@@ -388,21 +412,17 @@ func (p *Player) CanTradeIn(a *Area) bool {
 
 func (p *Player) canTradeIn(a *Area) error {
 	g := p.Game()
-	switch {
+	switch err := p.canPerform("trade-resource"); {
 	case a == nil:
 		return sn.NewVError("No area selected.")
 	case g.Phase != Actions:
 		return sn.NewVError("You can not trade during the %s phase.", g.PhaseName())
-	case g.MultiAction != tradedResourceMA && g.MultiAction != noMultiAction:
-		return sn.NewVError("You have another action in progress.")
 	case !p.IsCurrentPlayer():
 		return sn.NewVError("Only the current player can trade.")
 	case p.Passed:
 		return sn.NewVError("You can not trade after passing.")
-	case g.anyPassed() && !p.PaidActionCost:
-		return sn.NewVError("After other players pass, you must pay action cost to trade.")
-	case p.PerformedAction && g.MultiAction != tradedResourceMA:
-		return sn.NewVError("You have already performed an action.")
+	case err != nil:
+		return err
 	case a.IsSumer():
 		return sn.NewVError("You can't trade resources in Sumer.")
 	case p.availableTradersIn(a) < 1:
@@ -416,105 +436,136 @@ func (p *Player) CanMakeToolIn(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	return g.Phase == Actions &&
-		!(p.PerformedAction && g.MultiAction != tradedResourceMA) &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		((g.anyPassed() && p.PaidActionCost) || !g.anyPassed()) &&
-		a.ID == ToolMakers &&
-		p.Resources[Metal] > 0 &&
-		p.WorkersIn(a) > 0
+	return p.canMakeToolIn(a) == nil
+}
+
+func (p *Player) canMakeToolIn(a *Area) error {
+	switch err := p.canAct("make-tool", Actions); {
+	case err != nil:
+		return err
+	case a == nil:
+		return sn.NewVError("No area selected.")
+	case a.ID != ToolMakers:
+		return sn.NewVError("You can't make a tool in %s.", a.Name())
+	case p.Resources[Metal] < 1:
+		return sn.NewVError("You don't have a metal with which to make a tool.")
+	case p.WorkersIn(a) < 1:
+		return sn.NewVError("You don't have a toolmaker with which to make a tool.")
+	default:
+		return nil
+	}
 }
 
 func (p *Player) CanStartEmpireIn(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
+	return p.canStartEmpireIn(a) == nil
+}
+
+func (p *Player) canStartEmpireIn(a *Area) error {
+	if err := p.canAct("start-empire", Actions); err != nil {
+		return err
+	}
+
+	if a == nil {
+		return sn.NewVError("No area selected.")
+	}
+
+	if p.empire() != nil {
+		return sn.NewVError("You have already started an empire this turn.")
+	}
+
 	aid := a.ID
 	if a.IsSumer() {
 		aid = Sumer
 	}
 
 	availableEmpire := false
-	for _, empire := range g.CurrentEmpires() {
-		owner := empire.Owner()
-		if owner == nil && aid == empire.AreaID && p.empire() == nil {
+	for _, empire := range p.Game().CurrentEmpires() {
+		if empire.Owner() == nil && aid == empire.AreaID {
 			availableEmpire = true
 		}
 	}
-	return g.Phase == Actions &&
-		g.MultiAction == noMultiAction &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		((g.anyPassed() && p.PaidActionCost) || !g.anyPassed()) &&
-		!p.PerformedAction &&
-		availableEmpire &&
-		p.hasSameOrMoreWorkersIn(a)
+
+	switch {
+	case !availableEmpire:
+		return sn.NewVError("There is no empire available to start in %s.", a.Name())
+	case !p.hasSameOrMoreWorkersIn(a):
+		return sn.NewVError("You don't have enough workers in %s to start an empire.", a.Name())
+	default:
+		return nil
+	}
 }
 
 func (p *Player) CanBuyArmiesForArmyIn(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	aid := a.ID
-	if a.IsSumer() {
-		aid = Sumer
+	return p.canBuyArmiesForArmyIn(a) == nil
+}
+
+func (p *Player) canBuyArmiesForArmyIn(a *Area) error {
+	if err := p.canAct("buy-armies", Actions); err != nil {
+		return err
 	}
-	return g.Phase == Actions &&
-		g.MultiAction == startedEmpireMA &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		p.empire() != nil &&
-		p.empire().AreaID == aid
+	return p.isEmpireIn(a)
 }
 
 func (p *Player) CanEquipArmyIn(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	aid := a.ID
-	if a.IsSumer() {
-		aid = Sumer
+	return p.canEquipArmyIn(a) == nil
+}
+
+func (p *Player) canEquipArmyIn(a *Area) error {
+	if err := p.canAct("equip-army", Actions); err != nil {
+		return err
 	}
-	return g.Phase == Actions &&
-		g.MultiAction == boughtArmiesMA &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		p.empire() != nil &&
-		p.empire().AreaID == aid
+	return p.isEmpireIn(a)
 }
 
 func (p *Player) CanPlaceArmyIn(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
+	return p.canPlaceArmyIn(a) == nil
+}
+
+func (p *Player) canPlaceArmyIn(a *Area) error {
+	if err := p.canAct("place-armies", Actions); err != nil {
+		return err
+	}
+	return p.isEmpireIn(a)
+}
+
+// isEmpireIn returns an error unless a is the area of the empire p started this turn.
+func (p *Player) isEmpireIn(a *Area) error {
+	if a == nil {
+		return sn.NewVError("No area selected.")
+	}
+
 	aid := a.ID
 	if a.IsSumer() {
 		aid = Sumer
 	}
-	return g.Phase == Actions &&
-		g.MultiAction == equippedArmyMA &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		((g.anyPassed() && p.PaidActionCost) || !g.anyPassed()) &&
-		p.empire() != nil &&
-		p.empire().AreaID == aid
+
+	switch empire := p.empire(); {
+	case empire == nil:
+		return sn.NewVError("You have not started an empire.")
+	case empire.AreaID != aid:
+		return sn.NewVError("Your empire is not in %s.", a.Name())
+	default:
+		return nil
+	}
 }
 
 func (p *Player) CanPass() bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	return g.Phase == Actions &&
-		g.MultiAction == noMultiAction &&
-		p.IsCurrentPlayer() &&
-		!p.Passed
+	return p.canAct("pass", Actions) == nil
 }
 
 func (p *Player) CanExpandEmpireIn(a *Area) bool {
@@ -525,13 +576,22 @@ func (p *Player) CanExpandCityIn(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	return g.Phase == ExpandCity &&
-		g.MultiAction == noMultiAction &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		p.hasCityIn(a.ID) &&
-		p.Resources[Wood] >= 2
+	return p.canExpandCityIn(a) == nil
+}
+
+func (p *Player) canExpandCityIn(a *Area) error {
+	switch err := p.canAct("expand-city", ExpandCity); {
+	case err != nil:
+		return err
+	case a == nil:
+		return sn.NewVError("No area selected.")
+	case !p.hasCityIn(a.ID):
+		return sn.NewVError("You do not have a city in %s.", a.Name())
+	case p.Resources[Wood] < 2:
+		return sn.NewVError("You need two wood to expand a city.")
+	default:
+		return nil
+	}
 }
 
 func (p *Player) hasArmyAdjacentTo(a *Area) bool {
@@ -565,73 +625,117 @@ func (p *Player) CanReinforceArmyIn(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	return g.Phase == Actions &&
-		(g.MultiAction == noMultiAction || g.MultiAction == expandEmpireMA) &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		((g.anyPassed() && p.PaidActionCost) || !g.anyPassed()) &&
-		p.empire() != nil &&
-		p.ArmiesIn(a) == 1 &&
-		p.Army >= 1+g.expansionCost()
+	return p.canReinforceArmyIn(a) == nil
+}
+
+func (p *Player) canReinforceArmyIn(a *Area) error {
+	switch err := p.canAct("reinforce-army", Actions); {
+	case err != nil:
+		return err
+	case a == nil:
+		return sn.NewVError("No area selected.")
+	case p.empire() == nil:
+		return sn.NewVError("You have not started an empire.")
+	case p.ArmiesIn(a) != 1:
+		return sn.NewVError("You must have exactly one army in %s to reinforce it.", a.Name())
+	case p.Army < 1+p.Game().expansionCost():
+		return sn.NewVError("You need %d armies to reinforce your army in %s.", 1+p.Game().expansionCost(), a.Name())
+	default:
+		return nil
+	}
 }
 
 func (p *Player) CanInvade(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	cost := g.expansionCost()
-	if g.Continue {
-		cost = 0
+	return p.canInvade(a) == nil
+}
+
+func (p *Player) canInvade(a *Area) error {
+	cost := p.Game().invasionCost()
+	switch err := p.canAct("invade-area", Actions); {
+	case err != nil:
+		return err
+	case a == nil:
+		return sn.NewVError("No area selected.")
+	case p.empire() == nil:
+		return sn.NewVError("You have not started an empire.")
+	case !p.hasArmyAdjacentTo(a):
+		return sn.NewVError("You do not have an army adjacent to %s.", a.Name())
+	case a.ArmyOwner() != nil:
+		return sn.NewVError("%s is occupied by an army.", a.Name())
+	case p.Army < 1+cost:
+		return sn.NewVError("You need %d armies to invade %s.", 1+cost, a.Name())
+	default:
+		return nil
 	}
-	return g.Phase == Actions &&
-		(g.MultiAction == noMultiAction || g.MultiAction == expandEmpireMA) &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		((g.anyPassed() && p.PaidActionCost) || !g.anyPassed()) &&
-		p.empire() != nil &&
-		p.hasArmyAdjacentTo(a) &&
-		a.ArmyOwner() == nil &&
-		p.Army >= 1+cost
 }
 
 func (p *Player) CanInvadeWarning(a *Area) bool {
 	if p == nil {
 		return false
 	}
-	g := p.Game()
-	cost := g.expansionCost()
-	if g.Continue {
-		cost = 0
+	return p.canInvadeWarning(a) == nil
+}
+
+func (p *Player) canInvadeWarning(a *Area) error {
+	cost := p.Game().invasionCost()
+	switch err := p.canAct("invade-area-warning", Actions); {
+	case err != nil:
+		return err
+	case a == nil:
+		return sn.NewVError("No area selected.")
+	case p.empire() == nil:
+		return sn.NewVError("You have not started an empire.")
+	case !p.hasArmyAdjacentTo(a):
+		return sn.NewVError("You do not have an army adjacent to %s.", a.Name())
+	case a.ArmyOwner() == nil:
+		return sn.NewVError("%s is not occupied by an army.", a.Name())
+	case p.hasArmyIn(a):
+		return sn.NewVError("You already have an army in %s.", a.Name())
+	case p.Army < 1+cost:
+		return sn.NewVError("You need %d armies to invade %s.", 1+cost, a.Name())
+	default:
+		return nil
 	}
-	return g.Phase == Actions &&
-		(g.MultiAction == noMultiAction || g.MultiAction == expandEmpireMA) &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		((g.anyPassed() && p.PaidActionCost) || !g.anyPassed()) &&
-		p.empire() != nil &&
-		p.hasArmyAdjacentTo(a) &&
-		a.ArmyOwner() != nil &&
-		!p.hasArmyIn(a) &&
-		p.Army >= 1+cost
 }
 
 func (p *Player) CanDestroyCityIn(a *Area) bool {
 	if p == nil {
 		return false
 	}
+	return p.canDestroyCityIn(a) == nil
+}
+
+func (p *Player) canDestroyCityIn(a *Area) error {
 	g := p.Game()
-	return g.Phase == Actions &&
-		(g.MultiAction == noMultiAction || g.MultiAction == expandEmpireMA) &&
-		p.IsCurrentPlayer() &&
-		!p.Passed &&
-		((g.anyPassed() && p.PaidActionCost) || !g.anyPassed()) &&
-		p.empire() != nil &&
-		p.hasArmyIn(a) &&
-		a.City.Built &&
-		!p.hasCityIn(a.ID) &&
-		p.Army >= g.expansionCost()+g.destructionCostIn(a)
+	switch err := p.canAct("destroy-city", Actions); {
+	case err != nil:
+		return err
+	case a == nil:
+		return sn.NewVError("No area selected.")
+	case p.empire() == nil:
+		return sn.NewVError("You have not started an empire.")
+	case !p.hasArmyIn(a):
+		return sn.NewVError("You do not have an army in %s.", a.Name())
+	case !a.City.Built:
+		return sn.NewVError("There is no city in %s.", a.Name())
+	case p.hasCityIn(a.ID):
+		return sn.NewVError("You can not destroy your own city.")
+	case p.Army < g.expansionCost()+g.destructionCostIn(a):
+		return sn.NewVError("You need %d armies to destroy the city in %s.", g.expansionCost()+g.destructionCostIn(a), a.Name())
+	default:
+		return nil
+	}
+}
+
+// invasionCost is the expansion cost of an invasion, which is waived when continuing an invasion.
+func (g *Game) invasionCost() int {
+	if g.Continue {
+		return 0
+	}
+	return g.expansionCost()
 }
 
 func (g *Game) expansionCost() int {
@@ -642,15 +746,7 @@ func (g *Game) expansionCost() int {
 }
 
 func (g *Game) destructionCostIn(a *Area) int {
-	if a.City.Owner().hasShuruppakPrivilege() {
-		return 3
-	}
-	return 2
-}
-
-func (p *Player) hasShuruppakPrivilege() bool {
-	owner := p.Game().Areas[Shuruppak].City.Owner()
-	return p.City <= 2 && owner != nil && owner.Equal(p)
+	return 2 + a.City.Owner().privilegeBonus(onDestruction)
 }
 
 func (p *Player) empire() *Empire {
@@ -736,7 +832,6 @@ func (g *Game) beginningOfPhaseReset() {
 
 func (p *Player) clearActions() {
 	p.PerformedAction = false
-	p.Log = make(game.GameLog, 0)
 }
 
 func (p *Player) IsSelectingWorker() bool {
@@ -752,8 +847,8 @@ func (p *Player) tradersIn(a *Area) int {
 		traders = p.ArmiesIn(a)
 	}
 
-	if traders > 0 && p.CanUseSippar() {
-		return traders + 1
+	if traders > 0 {
+		return traders + p.privilegeBonus(onTrade)
 	}
 	return traders
 }
@@ -766,31 +861,32 @@ func (p *Player) hasAvailableTradersIn(a *Area) bool {
 	return p.availableTradersIn(a) > 0
 }
 
-func (g *Game) adminPlayer(c *gin.Context, cu *user.User) (string, game.ActionType, error) {
+type adminPlayerForm struct {
+	IDF             int       `form:"idf"`
+	PerformedAction bool      `form:"performed-action"`
+	Score           int       `form:"score"`
+	Passed          bool      `form:"passed"`
+	Resources       Resources `form:"resources"`
+	City            int       `form:"city"`
+	Expansion       int       `form:"expansion"`
+	Worker          int       `form:"worker"`
+	WorkerSupply    int       `form:"worker-supply"`
+	Army            int       `form:"army"`
+	ArmySupply      int       `form:"army-supply"`
+	PassedResources Resources `form:"passed-resources"`
+	PaidActionCost  bool      `form:"paid-action-cost"`
+	UsedSippar      bool      `form:"used-sippar"`
+	VPPassed        bool      `form:"vp-passed"`
+}
+
+func (g *Game) adminPlayer(c *gin.Context, cu *user.User, args *actionArgs) (string, game.ActionType, error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
 	p := g.SelectedPlayer()
 	//np := newPlayer()
-	np := struct {
-		IDF             int       `form:"idf"`
-		PerformedAction bool      `form:"performed-action"`
-		Score           int       `form:"score"`
-		Passed          bool      `form:"passed"`
-		Resources       Resources `form:"resources"`
-		City            int       `form:"city"`
-		Expansion       int       `form:"expansion"`
-		Worker          int       `form:"worker"`
-		WorkerSupply    int       `form:"worker-supply"`
-		Army            int       `form:"army"`
-		ArmySupply      int       `form:"army-supply"`
-		PassedResources Resources `form:"passed-resources"`
-		PaidActionCost  bool      `form:"paid-action-cost"`
-		UsedSippar      bool      `form:"used-sippar"`
-		VPPassed        bool      `form:"vp-passed"`
-	}{}
-
-	err := c.ShouldBind(&np)
+	var np adminPlayerForm
+	err := args.bind(&np)
 	if err != nil {
 		return "", game.None, err
 	}
@@ -812,7 +908,9 @@ func (g *Game) adminPlayer(c *gin.Context, cu *user.User) (string, game.ActionTy
 	p.Passed = np.Passed
 	p.VPPassed = np.VPPassed
 	p.PerformedAction = np.PerformedAction
-	p.Score = np.Score
+	if np.Score != p.Score {
+		p.addScore(adjustedScore, NoArea, np.Score-p.Score)
+	}
 	p.PassedResources = np.PassedResources
 	p.PaidActionCost = np.PaidActionCost
 	return "", game.Save, nil
@@ -0,0 +1,58 @@
+package atf
+
+import (
+	"net/http"
+
+	"github.com/SlothNinja/restful"
+	"github.com/gin-gonic/gin"
+)
+
+// Actions whose effects depend on dice rolls.
+// A preview of such an action reports one possible outcome.
+var randomActions = sslice{"confirm-start-empire", "confirm-invasion"}
+
+// preview performs the posted action on a copy of the game and reports the resulting changes.
+// Nothing is cached or saved, and the action is not counted in the action stats.
+func (client *Client) preview(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		cu, err := client.User.Current(c)
+		if err != nil {
+			client.Log.Debugf(err.Error())
+		}
+
+		g2, err := client.deepCopy(c, g)
+		if err != nil {
+			client.Log.Errorf(err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		a := c.PostForm("action")
+		_, _, err = g2.tryUpdate(c, cu)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"action": a,
+				"valid":  false,
+				"error":  err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"action":  a,
+			"valid":   true,
+			"random":  randomActions.include(a),
+			"diff":    diffGames(g, g2),
+			"notices": restful.NoticesFrom(c),
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package atf
+
+import (
+	"encoding/gob"
+	"html/template"
+	"math/rand"
+	"net/http"
+	"sort"
+
+	"github.com/SlothNinja/restful"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gob.Register(new(privilegesEntry))
+}
+
+// privilegeTrigger identifies when a city privilege takes effect.
+type privilegeTrigger int
+
+const (
+	onBuild privilegeTrigger = iota
+	onIncome
+	onTrade
+	onScoring
+	onDestruction
+	onStartEmpire
+)
+
+var privilegeTriggerNames = map[privilegeTrigger]string{
+	onBuild:       "build",
+	onIncome:      "income",
+	onTrade:       "trade",
+	onScoring:     "scoring",
+	onDestruction: "destruction",
+	onStartEmpire: "start empire",
+}
+
+func (t privilegeTrigger) String() string {
+	return privilegeTriggerNames[t]
+}
+
+// cityPrivilege is an ability of the owner of a city.
+type cityPrivilege struct {
+	Name        string
+	Trigger     privilegeTrigger
+	Description string
+	// Bonus is the amount the privilege adds when triggered.
+	Bonus int
+	// Applies, if provided, further conditions the privilege for the owner of the city.
+	Applies func(p *Player) bool
+	// Build, for privileges triggered on building the city, performs the privilege and returns the reason logged.
+	Build func(p *Player) int
+}
+
+var cityPrivileges = map[string]cityPrivilege{
+	"Toolmaker": {
+		Name:        "Toolmaker",
+		Trigger:     onBuild,
+		Description: "Receive a toolmaker when building the city.",
+		Build:       (*Player).collectToolmakerPrivilege,
+	},
+	"Scribe": {
+		Name:        "Scribe",
+		Trigger:     onBuild,
+		Description: "Receive a scribe when building the city.",
+		Build:       (*Player).collectScribePrivilege,
+	},
+	"Reinforcements": {
+		Name:        "Reinforcements",
+		Trigger:     onStartEmpire,
+		Description: "Receive 2 additional armies when starting an empire.",
+		Bonus:       2,
+	},
+	"Trader": {
+		Name:        "Trader",
+		Trigger:     onTrade,
+		Description: "Once per turn, trade with an additional trader or trade an already traded resource.",
+		Bonus:       1,
+		Applies:     func(p *Player) bool { return !p.UsedSippar },
+	},
+	"Sumerian Empire": {
+		Name:        "Sumerian Empire",
+		Trigger:     onScoring,
+		Description: "Score 1 additional point for an empire in Sumer.",
+		Bonus:       1,
+	},
+	"Textile": {
+		Name:        "Textile",
+		Trigger:     onIncome,
+		Description: "Receive 1 additional textile when collecting textile.",
+		Bonus:       1,
+	},
+	"Fortification": {
+		Name:        "Fortification",
+		Trigger:     onDestruction,
+		Description: "Destroying the owner's cities costs 1 additional army while the owner has 2 or fewer cities in supply.",
+		Bonus:       1,
+		Applies:     func(p *Player) bool { return p.City <= 2 },
+	},
+}
+
+// privilegeSet assigns privileges to cities.
+type privilegeSet map[AreaID]string
+
+var privilegeSets = map[string]privilegeSet{
+	defaultPrivilegeSet: {
+		Eridu:     "Toolmaker",
+		Uruk:      "Scribe",
+		Babylon:   "Reinforcements",
+		Sippar:    "Trader",
+		Nippur:    "Sumerian Empire",
+		Ur:        "Textile",
+		Shuruppak: "Fortification",
+	},
+}
+
+const (
+	defaultPrivilegeSet  = "standard"
+	shuffledPrivilegeSet = "shuffled"
+)
+
+// setupPrivileges deals the privileges of the cities at the start of the game.
+func (g *Game) setupPrivileges() {
+	if g.variant()["privileges"] == shuffledPrivilegeSet {
+		g.PrivilegeSeed = newSeed()
+	}
+	g.Privileges = g.dealPrivileges()
+}
+
+// dealPrivileges returns the privileges of the cities for the variant of the game.
+// The shuffled variant deals the standard privileges to the same cities using the seed of the game.
+func (g *Game) dealPrivileges() privilegeSet {
+	name := g.variant()["privileges"]
+	if name != shuffledPrivilegeSet {
+		if set, ok := privilegeSets[name]; ok {
+			return set
+		}
+		return privilegeSets[defaultPrivilegeSet]
+	}
+
+	standard := privilegeSets[defaultPrivilegeSet]
+	aids := make(AreaIDS, 0, len(standard))
+	for aid := range standard {
+		aids = append(aids, aid)
+	}
+	sort.Slice(aids, func(i, j int) bool { return aids[i] < aids[j] })
+
+	set := make(privilegeSet, len(aids))
+	perm := rand.New(rand.NewSource(g.PrivilegeSeed)).Perm(len(aids))
+	for i, aid := range aids {
+		set[aid] = standard[aids[perm[i]]]
+	}
+	return set
+}
+
+// privilegeSet returns the privileges of the cities of the game.
+func (g *Game) privilegeSet() privilegeSet {
+	if g.Privileges == nil {
+		g.Privileges = g.dealPrivileges()
+	}
+	return g.Privileges
+}
+
+// privilegeIn returns the privilege of the city in the area with the provided id.
+func (g *Game) privilegeIn(aid AreaID) (cityPrivilege, bool) {
+	priv, ok := cityPrivileges[g.privilegeSet()[aid]]
+	return priv, ok
+}
+
+// privilege returns the privilege of p having the provided trigger, if any.
+func (p *Player) privilege(t privilegeTrigger) (cityPrivilege, bool) {
+	if p == nil {
+		return cityPrivilege{}, false
+	}
+
+	set := p.Game().privilegeSet()
+	for _, aid := range areaIDS() {
+		priv, ok := cityPrivileges[set[aid]]
+		if !ok || priv.Trigger != t || !p.hasCityIn(aid) {
+			continue
+		}
+		if priv.Applies == nil || priv.Applies(p) {
+			return priv, true
+		}
+	}
+	return cityPrivilege{}, false
+}
+
+// privilegeBonus returns the bonus of the privilege of p having the provided trigger, or 0 if none.
+func (p *Player) privilegeBonus(t privilegeTrigger) int {
+	if priv, ok := p.privilege(t); ok {
+		return priv.Bonus
+	}
+	return 0
+}
+
+// CityPrivilege describes a city privilege and its current holder for display on the board.
+type CityPrivilege struct {
+	Area        string `json:"area"`
+	Name        string `json:"name"`
+	Trigger     string `json:"trigger"`
+	Description string `json:"description"`
+	HolderID    int    `json:"holderId"`
+	Holder      string `json:"holder,omitempty"`
+}
+
+// CityPrivileges returns the privileges of the cities of the game, ordered by area.
+func (g *Game) CityPrivileges() []CityPrivilege {
+	var cps []CityPrivilege
+	for _, aid := range areaIDS() {
+		priv, ok := g.privilegeIn(aid)
+		if !ok {
+			continue
+		}
+
+		cp := CityPrivilege{
+			Area:        aid.Name(),
+			Name:        priv.Name,
+			Trigger:     priv.Trigger.String(),
+			Description: priv.Description,
+			HolderID:    NoPlayerID,
+		}
+		if owner := g.Areas[aid].City.Owner(); owner != nil {
+			cp.HolderID = owner.ID()
+			cp.Holder = g.NameFor(owner)
+		}
+		cps = append(cps, cp)
+	}
+	return cps
+}
+
+type privilegesEntry struct {
+	*Entry
+	Privileges []CityPrivilege
+}
+
+func (g *Game) newPrivilegesEntry() {
+	e := &privilegesEntry{
+		Entry:      g.newEntry(),
+		Privileges: g.CityPrivileges(),
+	}
+	g.Log = append(g.Log, e)
+}
+
+func (e *privilegesEntry) HTML() template.HTML {
+	s := restful.HTML("<div>The city privileges were shuffled as follows:</div><div>&nbsp;</div>")
+	s += restful.HTML("<table class='strippedDataTable'><thead><tr><th>City</th><th>Privilege</th></tr></thead><tbody>")
+	for _, cp := range e.Privileges {
+		s += restful.HTML("<tr><td>%s</td><td>%s: %s</td></tr>", cp.Area, cp.Name, cp.Description)
+	}
+	s += restful.HTML("</tbody></table>")
+	return s
+}
+
+// cityPrivilegeList lists the privileges of the cities of the game and their current holders.
+func (client *Client) cityPrivilegeList(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"privileges": g.CityPrivileges()})
+	}
+}
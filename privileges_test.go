@@ -0,0 +1,44 @@
+package atf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetupPrivileges(t *testing.T) {
+	tests := []struct {
+		name       string
+		privileges string
+	}{
+		{"standard", ""},
+		{"shuffled", shuffledPrivilegeSet},
+	}
+
+	for _, test := range tests {
+		g := newTestGame()
+		if test.privileges != "" {
+			g.Options = []string{"privileges=" + test.privileges}
+		}
+		g.setupPrivileges()
+
+		if !reflect.DeepEqual(g.privilegeSet(), g.dealPrivileges()) {
+			t.Errorf("%s: privilegeSet() = %v, want the set dealt by the seed %v", test.name, g.privilegeSet(), g.dealPrivileges())
+		}
+		if test.privileges == "" && !reflect.DeepEqual(g.Privileges, privilegeSets[defaultPrivilegeSet]) {
+			t.Errorf("%s: Privileges = %v, want %v", test.name, g.Privileges, privilegeSets[defaultPrivilegeSet])
+		}
+
+		got := map[string]int{}
+		for _, name := range g.Privileges {
+			got[name]++
+		}
+		for aid, name := range privilegeSets[defaultPrivilegeSet] {
+			if got[name] != 1 {
+				t.Errorf("%s: privilege %s dealt %d times, want 1", test.name, name, got[name])
+			}
+			if _, ok := g.Privileges[aid]; !ok {
+				t.Errorf("%s: no privilege dealt to %s", test.name, aid.Name())
+			}
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package atf
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/datastore"
+	"github.com/SlothNinja/codec"
+	"github.com/SlothNinja/contest"
+	"github.com/SlothNinja/game"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ratingModelEnv   = "ATF_RATING_MODEL"
+	ratingWeightsEnv = "ATF_RATING_WEIGHTS"
+)
+
+// ratingModel provides the rating contests of a finished game.
+// Outcome returns the outcome for a player placing at place1 against a player placing at place2,
+// and whether the pair is rated at all.  Places are numbered from 1 and tied players share a place.
+type ratingModel struct {
+	Name    string
+	Outcome func(place1, place2 int) (float64, bool)
+}
+
+var ratingModels = map[string]ratingModel{
+	"pairwise": pairwiseModel(),
+	"adjacent": adjacentModel(),
+}
+
+const defaultRatingModel = "pairwise"
+
+var errAppliedContests = errors.New("Contests of the game have been applied to ratings and can not be replaced.")
+
+// pairwiseModel rates every pair of players: a win for the better placed player and a draw for a shared place.
+func pairwiseModel() ratingModel {
+	return ratingModel{
+		Name: "pairwise",
+		Outcome: func(place1, place2 int) (float64, bool) {
+			switch {
+			case place1 < place2:
+				return 1, true
+			case place1 > place2:
+				return 0, true
+			}
+			return 0.5, true
+		},
+	}
+}
+
+// adjacentModel rates only players in the same or neighbouring places.
+func adjacentModel() ratingModel {
+	pairwise := pairwiseModel()
+	return ratingModel{
+		Name: "adjacent",
+		Outcome: func(place1, place2 int) (float64, bool) {
+			if gap := place1 - place2; gap > 1 || gap < -1 {
+				return 0, false
+			}
+			return pairwise.Outcome(place1, place2)
+		},
+	}
+}
+
+// placementModel rates every pair of players, weighting the win by the gap in places.
+// weights[i] is the weight of a gap of i+1 places; gaps beyond the weights use the last weight.
+// A weight of 1 is a full win, and a weight of 0 a draw.
+func placementModel(weights ...float64) ratingModel {
+	return ratingModel{
+		Name: "placement",
+		Outcome: func(place1, place2 int) (float64, bool) {
+			gap := place2 - place1
+			if gap == 0 || len(weights) == 0 {
+				return pairwiseModel().Outcome(place1, place2)
+			}
+
+			sign := 1.0
+			if gap < 0 {
+				gap, sign = -gap, -1.0
+			}
+			if gap > len(weights) {
+				gap = len(weights)
+			}
+			return 0.5 + sign*weights[gap-1]/2, true
+		},
+	}
+}
+
+// ratingModelFromEnv returns the rating model selected for the deployment.
+// The placement model takes its weights from a comma separated list (e.g., "0.6,0.8,1").
+func ratingModelFromEnv() (ratingModel, error) {
+	return parseRatingModel(os.Getenv(ratingModelEnv), os.Getenv(ratingWeightsEnv))
+}
+
+// parseRatingModel returns the rating model having the name, by default the pairwise model.
+// weights are used only by the placement model, and each must be between 0 and 1.
+func parseRatingModel(name, weights string) (ratingModel, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ratingModels[defaultRatingModel], nil
+	}
+
+	if name != "placement" {
+		m, ok := ratingModels[name]
+		if !ok {
+			return ratingModel{}, fmt.Errorf("%s: unknown rating model %q", ratingModelEnv, name)
+		}
+		return m, nil
+	}
+
+	var ws []float64
+	for _, s := range strings.Split(weights, ",") {
+		w, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil || w < 0 || w > 1 {
+			return ratingModel{}, fmt.Errorf("%s: invalid weight %q; weights must be between 0 and 1", ratingWeightsEnv, s)
+		}
+		ws = append(ws, w)
+	}
+	return placementModel(ws...), nil
+}
+
+// recomputeContests regenerates the contests of completed games using the rating model of the deployment,
+// and, if apply is set, replaces the stored contests of each game.
+// Contests are regenerated using the current ratings of opponents, and are stored unapplied.
+// Games having contests already applied to ratings are reported and left unchanged,
+// as replacing applied contests would leave their outcomes in the ratings.
+func (client *Client) recomputeContests(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		cu, err := client.User.Current(c)
+		if err != nil || !cu.IsAdmin() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only an admin can recompute contests."})
+			return
+		}
+
+		apply := c.Query("apply") == "true"
+
+		q := datastore.NewQuery(kind).
+			Ancestor(pk(c)).
+			Filter("Status=", int(game.Completed)).
+			KeysOnly()
+
+		ks, err := client.DS.GetAll(c, q, nil)
+		if err != nil {
+			client.Log.Errorf(err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		games := make([]gin.H, 0, len(ks))
+		for _, k := range ks {
+			cs, replaced, err := client.recomputeContestsFor(c, k.ID, apply)
+			if err != nil {
+				client.Log.Errorf("game %d: %v", k.ID, err)
+				games = append(games, gin.H{"gameId": k.ID, "error": err.Error()})
+				continue
+			}
+			games = append(games, gin.H{"gameId": k.ID, "contests": len(cs), "replaced": replaced})
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"model":   client.ratingModel.Name,
+			"applied": apply,
+			"games":   games,
+		})
+	}
+}
+
+// recomputeContestsFor regenerates the contests of the game with the provided id.
+// Returns the regenerated contests and the number of stored contests they replace.
+// Returns errAppliedContests if any stored contest has been applied.
+func (client *Client) recomputeContestsFor(c *gin.Context, id int64, apply bool) ([]*contest.Contest, int, error) {
+	g := New(c, id)
+	err := client.getHeader(c, g)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s := newState()
+	err = codec.Decode(&s, g.SavedState)
+	if err != nil {
+		return nil, 0, err
+	}
+	g.State = s
+
+	err = client.init(c, g)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	places, err := client.determinePlaces(c, g)
+	if err != nil {
+		return nil, 0, err
+	}
+	v := g.variant()
+	cs := contest.GenContests(c, places)
+
+	old, err := client.DS.GetAll(c, v.contestQuery(g.ID()).KeysOnly(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	oes, ocs := v.newContests(len(old))
+	err = client.DS.GetMulti(c, old, oes)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = checkUnapplied(ocs)
+	if err != nil || !apply {
+		return cs, len(old), err
+	}
+
+	ks, es := v.contestEntities(cs)
+	_, err = client.DS.RunInTransaction(c, func(tx *datastore.Transaction) error {
+		// contests may have been applied since checked
+		oes, ocs := v.newContests(len(old))
+		err := tx.GetMulti(old, oes)
+		if err != nil {
+			return err
+		}
+
+		err = checkUnapplied(ocs)
+		if err != nil {
+			return err
+		}
+
+		err = tx.DeleteMulti(old)
+		if err != nil {
+			return err
+		}
+		_, err = tx.PutMulti(ks, es)
+		return err
+	})
+	return cs, len(old), err
+}
+
+// checkUnapplied returns errAppliedContests if any of cs has been applied.
+func checkUnapplied(cs []*contest.Contest) error {
+	for _, ct := range cs {
+		if ct.Applied {
+			return errAppliedContests
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package atf
+
+import "testing"
+
+func TestRatingModels(t *testing.T) {
+	tests := []struct {
+		name           string
+		model          ratingModel
+		place1, place2 int
+		want           float64
+		rated          bool
+	}{
+		{"pairwise win", pairwiseModel(), 1, 3, 1, true},
+		{"pairwise loss", pairwiseModel(), 3, 2, 0, true},
+		{"pairwise draw", pairwiseModel(), 2, 2, 0.5, true},
+		{"adjacent win", adjacentModel(), 1, 2, 1, true},
+		{"adjacent draw", adjacentModel(), 1, 1, 0.5, true},
+		{"adjacent unrated", adjacentModel(), 1, 3, 0, false},
+		{"placement gap of one", placementModel(0.6, 1), 1, 2, 0.8, true},
+		{"placement gap of two", placementModel(0.6, 1), 1, 3, 1, true},
+		{"placement loss", placementModel(0.6, 1), 2, 1, 0.2, true},
+		{"placement beyond weights", placementModel(0.6), 1, 3, 0.8, true},
+		{"placement draw", placementModel(0.6, 1), 2, 2, 0.5, true},
+		{"placement without weights", placementModel(), 1, 3, 1, true},
+	}
+
+	for _, test := range tests {
+		got, rated := test.model.Outcome(test.place1, test.place2)
+		if got != test.want || rated != test.rated {
+			t.Errorf("%s: Outcome(%d, %d) = %v, %v; want %v, %v",
+				test.name, test.place1, test.place2, got, rated, test.want, test.rated)
+		}
+	}
+}
+
+func TestParseRatingModel(t *testing.T) {
+	tests := []struct {
+		name, model, weights string
+		want                 string
+		wantErr              bool
+	}{
+		{"default", "", "", "pairwise", false},
+		{"adjacent", "Adjacent", "", "adjacent", false},
+		{"placement", "placement", "0.5, 1", "placement", false},
+		{"unknown model", "elo", "", "", true},
+		{"missing weights", "placement", "", "", true},
+		{"invalid weight", "placement", "0.5,x", "", true},
+		{"weight out of range", "placement", "0.5,1.5", "", true},
+	}
+
+	for _, test := range tests {
+		m, err := parseRatingModel(test.model, test.weights)
+		switch {
+		case test.wantErr && err == nil:
+			t.Errorf("%s: parseRatingModel(%q, %q) succeeded; want error", test.name, test.model, test.weights)
+		case !test.wantErr && err != nil:
+			t.Errorf("%s: parseRatingModel(%q, %q) = %v", test.name, test.model, test.weights, err)
+		case m.Name != test.want:
+			t.Errorf("%s: parseRatingModel(%q, %q) = %q; want %q", test.name, test.model, test.weights, m.Name, test.want)
+		}
+	}
+}
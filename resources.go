@@ -2,8 +2,6 @@ package atf
 
 import (
 	"strings"
-
-	"github.com/gin-gonic/gin"
 )
 
 type Resource int
@@ -181,28 +179,13 @@ func (g *Game) TradesFor(i int) Resources {
 	return Resource(i).trade()
 }
 
-func getResourcesFrom(c *gin.Context) (Resources, error) {
-	rs := struct {
-		Grain   int `form:"grain"`
-		Wood    int `form:"wood"`
-		Metal   int `form:"metal"`
-		Textile int `form:"textile"`
-		Tool    int `form:"tool"`
-		Oil     int `form:"oil"`
-		Gold    int `form:"gold"`
-		Lapis   int `form:"lapis"`
-	}{}
-
-	err := c.ShouldBind(&rs)
+func getResourcesFrom(args *actionArgs) (Resources, error) {
+	var rs rs
+	err := args.bind(&rs)
 	if err != nil {
 		return nil, err
 	}
 
-	// rs := new(rs)
-	// if err := restful.BindWith(c, rs, binding.FormPost); err != nil {
-	// 	return nil, err
-	// }
-
 	resources := make(Resources, 8)
 	resources[Grain] = rs.Grain
 	resources[Wood] = rs.Wood
@@ -15,15 +15,33 @@ type Client struct {
 	MLog   *mlog.Client
 	Game   *game.Client
 	Rating *rating.Client
+	locks  *gameLocks
+
+	subscribers []Subscriber
+	ratingModel ratingModel
 }
 
 func NewClient(snClient *sn.Client, uClient *user.Client, gClient *game.Client, rClient *rating.Client, t gtype.Type) *Client {
+	// invalid rating model or map configuration is a deployment error
+	model, err := ratingModelFromEnv()
+	if err != nil {
+		panic(err)
+	}
+
+	err = registerMapsFromEnv()
+	if err != nil {
+		panic(err)
+	}
+
 	client := &Client{
 		Client: snClient,
 		User:   uClient,
 		MLog:   mlog.NewClient(snClient, uClient),
 		Game:   gClient,
 		Rating: rClient,
+		locks:  newGameLocks(),
+
+		ratingModel: model,
 	}
 	return client.register(t)
 }
@@ -51,42 +69,149 @@ func (client *Client) addRoutes(prefix string) *Client {
 
 	// Undo
 	g.POST("/undo/:hid",
+		client.serialize,
 		client.fetch,
 		client.undo(prefix),
 	)
 
 	// Finish
 	g.POST("/finish/:hid",
+		client.serialize,
 		client.fetch,
+		game.SetAdmin(false),
+		client.idempotent,
 		client.User.StatsFetch,
 		client.finish(prefix),
 	)
 
 	// Drop
 	g.POST("/drop/:hid",
+		client.serialize,
 		client.fetch,
+		game.SetAdmin(false),
+		client.idempotent,
 		client.drop(prefix),
 	)
 
 	// Accept
 	g.POST("/accept/:hid",
+		client.serialize,
 		client.fetch,
+		game.SetAdmin(false),
+		client.idempotent,
 		client.accept(prefix),
 	)
 
 	// Update
 	g.PUT("/show/:hid",
+		client.serialize,
 		client.fetch,
 		game.SetAdmin(false),
+		client.idempotent,
 		client.update(prefix),
 	)
 
 	g.POST("show/:hid",
+		client.serialize,
 		client.fetch,
 		game.SetAdmin(false),
+		client.idempotent,
 		client.update(prefix),
 	)
 
+	// Action Docs
+	g.GET("/actions",
+		client.actionDocs(prefix),
+	)
+
+	// Recompute Contests
+	g.POST("/recompute-contests",
+		client.recomputeContests(prefix),
+	)
+
+	// Log
+	g.GET("/show/:hid/log",
+		client.fetch,
+		client.gameLog(prefix),
+	)
+
+	// State Diff
+	g.GET("/show/:hid/diff",
+		client.fetch,
+		client.stateDiff(prefix),
+	)
+
+	// Multi-Action State
+	g.GET("/show/:hid/actions",
+		client.fetch,
+		client.multiActions(prefix),
+	)
+
+	// Area Actions
+	g.GET("/show/:hid/area-actions",
+		client.fetch,
+		client.areaActions(prefix),
+	)
+
+	// Income Projection
+	g.GET("/show/:hid/income",
+		client.fetch,
+		client.incomeProjection(prefix),
+	)
+
+	// Majority Standings
+	g.GET("/show/:hid/standings",
+		client.fetch,
+		client.majorityStandings(prefix),
+	)
+
+	// Expansion Calculator
+	g.GET("/show/:hid/expansion",
+		client.fetch,
+		client.expansionCalculator(prefix),
+	)
+
+	// Trade Optimizer
+	g.GET("/show/:hid/trades",
+		client.fetch,
+		client.tradeOptimizer(prefix),
+	)
+
+	// Equipment Planner
+	g.GET("/show/:hid/equipment",
+		client.fetch,
+		client.equipmentPlanner(prefix),
+	)
+
+	// Score Ledger
+	g.GET("/show/:hid/ledger",
+		client.fetch,
+		client.scoreBreakdown(prefix),
+	)
+
+	// City Privileges
+	g.GET("/show/:hid/privileges",
+		client.fetch,
+		client.cityPrivilegeList(prefix),
+	)
+
+	// Batch Update
+	g.POST("/batch/:hid",
+		client.serialize,
+		client.fetch,
+		game.SetAdmin(false),
+		client.idempotent,
+		client.batch(prefix),
+	)
+
+	// Preview Update
+	g.POST("/preview/:hid",
+		client.serialize,
+		client.fetch,
+		game.SetAdmin(false),
+		client.preview(prefix),
+	)
+
 	// Add Message
 	g.PUT("/show/:hid/addmessage",
 		client.fetch,
@@ -120,12 +245,14 @@ func (client *Client) addRoutes(prefix string) *Client {
 
 	// Admin Update
 	admin.POST("/:hid",
+		client.serialize,
 		client.fetch,
 		game.SetAdmin(true),
 		client.update(prefix),
 	)
 
 	admin.PUT("/:hid",
+		client.serialize,
 		client.fetch,
 		game.SetAdmin(true),
 		client.update(prefix),
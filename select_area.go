@@ -7,13 +7,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func (g *Game) selectArea(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
-	var aid AreaID
-
-	if aid, err = g.validateSelectArea(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
+func (g *Game) selectArea(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
+	aid := args.Area
 
 	if aid == Player0 || aid == Player1 || aid == Player2 {
 		g.SelectedAreaID, tmpl, act = aid, "atf/admin/player_dialog", game.Cache
@@ -56,9 +51,9 @@ func (g *Game) selectArea(c *gin.Context, cu *user.User) (tmpl string, act game.
 	return
 }
 
-func (g *Game) validateSelectArea(c *gin.Context, cu *user.User) (AreaID, error) {
+func (g *Game) validateSelectArea(cu *user.User, args *actionArgs) error {
 	if !g.IsCurrentPlayer(cu) {
-		return NoArea, sn.NewVError("Only the current player can perform an action.")
+		return sn.NewVError("Only the current player can perform an action.")
 	}
-	return getAreaID(c), nil
+	return nil
 }
@@ -9,27 +9,20 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func (g *Game) fromStock(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) fromStock(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validateFromStock(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
 	g.CurrentPlayer().Worker -= 1
 	g.From = "Stock"
 	g.MultiAction = selectedWorkerMA
-	tmpl, act = "atf/select_worker_from_stock_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateFromStock(c *gin.Context, cu *user.User) (err error) {
-	switch err = g.validatePlayerAction(cu); {
+func (g *Game) validateFromStock(cu *user.User, args *actionArgs) (err error) {
+	switch err = g.validateMultiAction(cu, "from-stock"); {
 	case err != nil:
-	case g.MultiAction != usedScribeMA:
-		err = sn.NewVError("You cannot chose 'From Stock' at this time.")
 	case g.CurrentPlayer().Worker < 1:
 		err = sn.NewVError("You have no available workers to place.")
 	}
@@ -74,7 +67,7 @@ func (g *Game) validateSelectWorker(c *gin.Context, cu *user.User) (err error) {
 		cp *Player
 	)
 
-	switch cp, a, err = g.CurrentPlayer(), g.SelectedArea(), g.validatePlayerAction(cu); {
+	switch cp, a, err = g.CurrentPlayer(), g.SelectedArea(), g.validateMultiAction(cu, "select-worker"); {
 	case err != nil:
 	case a == nil:
 		err = sn.NewVError("No area selected.")
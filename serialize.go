@@ -0,0 +1,113 @@
+package atf
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/SlothNinja/restful"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	readAttempts = 3
+	readBackoff  = 50 * time.Millisecond
+)
+
+// ErrConflict indicates the game was updated by another request after it was loaded.
+var ErrConflict = errors.New("Game state changed unexpectantly.  Try again.")
+
+// gameLocks serializes updates to the same game within this process.
+// Updates from other instances are caught by the UpdatedAt check performed when saving.
+type gameLocks struct {
+	mu    sync.Mutex
+	locks map[int64]*gameLock
+}
+
+type gameLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newGameLocks() *gameLocks {
+	return &gameLocks{locks: make(map[int64]*gameLock)}
+}
+
+func (gls *gameLocks) lock(id int64) {
+	gls.mu.Lock()
+	gl, ok := gls.locks[id]
+	if !ok {
+		gl = new(gameLock)
+		gls.locks[id] = gl
+	}
+	gl.refs += 1
+	gls.mu.Unlock()
+
+	gl.Lock()
+}
+
+func (gls *gameLocks) unlock(id int64) {
+	gls.mu.Lock()
+	gl := gls.locks[id]
+	gl.refs -= 1
+	if gl.refs == 0 {
+		delete(gls.locks, id)
+	}
+	gls.mu.Unlock()
+
+	gl.Unlock()
+}
+
+// serialize ensures handlers that update a game run one at a time per game.
+func (client *Client) serialize(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	client.locks.lock(id)
+	defer client.locks.unlock(id)
+	c.Next()
+}
+
+// Only requests that do not mutate a game are idempotent.
+func isIdempotent(c *gin.Context) bool {
+	return c.Request.Method == http.MethodGet
+}
+
+// getHeader reads the game header, retrying failed reads for idempotent requests.
+func (client *Client) getHeader(c *gin.Context, g *Game) (err error) {
+	attempts := 1
+	if isIdempotent(c) {
+		attempts = readAttempts
+	}
+
+	for i := 0; i < attempts; i++ {
+		err = client.DS.Get(c, g.Key, g.Header)
+		if err == nil || errors.Is(err, datastore.ErrNoSuchEntity) || c.Err() != nil {
+			return err
+		}
+		client.Log.Warningf("attempt %d to read game %d failed: %v", i+1, g.ID(), err)
+		time.Sleep(readBackoff * time.Duration(i+1))
+	}
+	return err
+}
+
+// conflict responds to a request whose update lost a race with another update of the same game.
+// Clients requesting JSON receive a 409 response; others return to the game with an error notice.
+func (client *Client) conflict(c *gin.Context, prefix string) {
+	switch c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) {
+	case gin.MIMEJSON:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "conflict",
+			"message": ErrConflict.Error(),
+			"gameId":  c.Param(hParam),
+		})
+	default:
+		restful.AddErrorf(c, ErrConflict.Error())
+		c.Redirect(http.StatusSeeOther, showPath(prefix, c.Param(hParam)))
+	}
+}
@@ -0,0 +1,234 @@
+package atf
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/SlothNinja/game"
+	"github.com/gin-gonic/gin"
+)
+
+const snapshotKind = "Snapshot"
+
+// ErrNoSnapshot indicates no saved state exists for the requested point of a game,
+// e.g., the point precedes the recording of snapshots.
+var ErrNoSnapshot = errors.New("No saved state for the requested point of the game.")
+
+// snapshotPages is the number of log pages after which a snapshot is written,
+// even if neither the turn nor the phase of the game has changed.
+const snapshotPages = 4
+
+// snapshot persists the state of a game as of a log position as a child of the game entity.
+// A snapshot is written when a save starts a new turn or phase, or completes snapshotPages log pages.
+type snapshot struct {
+	Key        *datastore.Key `datastore:"__key__"`
+	Position   int
+	Turn       int
+	Phase      game.Phase
+	Round      int
+	SavedState []byte `datastore:",noindex"`
+	CreatedAt  time.Time
+}
+
+// snapshotRef identifies a snapshot.
+type snapshotRef struct {
+	ID       int64 `datastore:"-" json:"-"`
+	Position int   `json:"position"`
+	Turn     int   `json:"turn"`
+}
+
+// snapshotRefs are ordered by position.
+type snapshotRefs []snapshotRef
+
+// snapshot ids are a count of the snapshots written for a game, and thus start at 1.
+func snapshotKey(g *Game, id int64) *datastore.Key {
+	return datastore.IDKey(snapshotKind, id, g.Key)
+}
+
+// newSnapshot returns the next snapshot of the encoded state of g.
+func newSnapshot(g *Game) *snapshot {
+	return &snapshot{
+		Key:        snapshotKey(g, int64(g.SnapshotCount)),
+		Position:   g.LogLength,
+		Turn:       g.Turn,
+		Phase:      g.Phase,
+		Round:      g.Round,
+		SavedState: g.SavedState,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// snapshotDue reports whether saving g, previously saved as old with a log of base entries,
+// should write a snapshot.
+func (g *Game) snapshotDue(old *game.Header, base int) bool {
+	const entries = snapshotPages * logPageSize
+	switch {
+	case g.SnapshotCount == 0:
+		return true
+	case g.Turn != old.Turn, g.Phase != old.Phase:
+		return true
+	default:
+		return base/entries != g.LogLength/entries
+	}
+}
+
+// snapshotRefs returns the snapshots of g ordered by position.
+// The projection requires a composite index on Snapshot of ancestor, Position and Turn.
+func (client *Client) snapshotRefs(c *gin.Context, g *Game) (snapshotRefs, error) {
+	q := datastore.NewQuery(snapshotKind).
+		Ancestor(g.Key).
+		Project("Position", "Turn")
+
+	var refs snapshotRefs
+	ks, err := client.DS.GetAll(c, q, &refs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range refs {
+		refs[i].ID = ks[i].ID
+	}
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].Position == refs[j].Position {
+			return refs[i].ID < refs[j].ID
+		}
+		return refs[i].Position < refs[j].Position
+	})
+	return refs, nil
+}
+
+// atOrBefore returns the last snapshot at or before log position.
+func (refs snapshotRefs) atOrBefore(position int) (snapshotRef, bool) {
+	for i := len(refs) - 1; i >= 0; i-- {
+		if refs[i].Position <= position {
+			return refs[i], true
+		}
+	}
+	return snapshotRef{}, false
+}
+
+// forTurn returns the first snapshot of turn.
+func (refs snapshotRefs) forTurn(turn int) (snapshotRef, bool) {
+	for _, r := range refs {
+		if r.Turn == turn {
+			return r, true
+		}
+	}
+	return snapshotRef{}, false
+}
+
+// gameAt returns the game as of the snapshot identified by ref.
+func (client *Client) gameAt(c *gin.Context, g *Game, ref snapshotRef) (*Game, error) {
+	client.Log.Debugf(msgEnter)
+	defer client.Log.Debugf(msgExit)
+
+	s := new(snapshot)
+	err := client.DS.Get(c, snapshotKey(g, ref.ID), s)
+	if err != nil {
+		return nil, err
+	}
+
+	g2, err := client.decodeWith(c, g, s.SavedState)
+	if err != nil {
+		return nil, err
+	}
+	g2.Turn, g2.Phase, g2.Round = s.Turn, s.Phase, s.Round
+	return g2, nil
+}
+
+// refFrom returns the snapshot of refs for the log position or turn provided by the named query parameters.
+// ok is false if neither parameter was provided.
+func (refs snapshotRefs) refFrom(c *gin.Context, posParam, turnParam string) (ref snapshotRef, ok bool, err error) {
+	if v := c.Query(posParam); v != "" {
+		position, err := strconv.Atoi(v)
+		if err != nil {
+			return ref, true, err
+		}
+		ref, found := refs.atOrBefore(position)
+		if !found {
+			return ref, true, ErrNoSnapshot
+		}
+		return ref, true, nil
+	}
+
+	if v := c.Query(turnParam); v != "" {
+		turn, err := strconv.Atoi(v)
+		if err != nil {
+			return ref, true, err
+		}
+		ref, found := refs.forTurn(turn)
+		if !found {
+			return ref, true, ErrNoSnapshot
+		}
+		return ref, true, nil
+	}
+	return ref, false, nil
+}
+
+// stateDiff reports how the board changed between two points of a game.
+// Points are given as log positions (from, to) or turns (fromTurn, toTurn).
+// Without a to point, the diff runs to the current state of the game.
+func (client *Client) stateDiff(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		refs, err := client.snapshotRefs(c, g)
+		if err != nil {
+			client.Log.Errorf(err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		fromRef, ok, err := refs.refFrom(c, "from", "fromTurn")
+		switch {
+		case !ok:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from or fromTurn is required"})
+			return
+		case err != nil:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		toRef, ok, err := refs.refFrom(c, "to", "toTurn")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		g1, err := client.gameAt(c, g, fromRef)
+		if err != nil {
+			client.Log.Errorf(err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		g2 := g
+		if ok {
+			g2, err = client.gameAt(c, g, toRef)
+			if err != nil {
+				client.Log.Errorf(err.Error())
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		} else {
+			toRef = snapshotRef{Position: g.LogLength + len(g.Log), Turn: g.Turn}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"from": fromRef,
+			"to":   toRef,
+			"diff": diffGames(g1, g2),
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package atf
+
+import (
+	"testing"
+
+	"github.com/SlothNinja/game"
+)
+
+func TestSnapshotRefsAtOrBefore(t *testing.T) {
+	refs := snapshotRefs{
+		{ID: 1, Position: 0, Turn: 0},
+		{ID: 2, Position: 12, Turn: 1},
+		{ID: 3, Position: 40, Turn: 2},
+	}
+
+	tests := []struct {
+		name     string
+		refs     snapshotRefs
+		position int
+		want     snapshotRef
+		found    bool
+	}{
+		{"none", nil, 5, snapshotRef{}, false},
+		{"before first", refs[1:], 5, snapshotRef{}, false},
+		{"first", refs, 0, refs[0], true},
+		{"between", refs, 11, refs[0], true},
+		{"exact", refs, 12, refs[1], true},
+		{"after last", refs, 100, refs[2], true},
+	}
+
+	for _, test := range tests {
+		got, found := test.refs.atOrBefore(test.position)
+		if got != test.want || found != test.found {
+			t.Errorf("%s: atOrBefore(%d) = %v, %v; want %v, %v",
+				test.name, test.position, got, found, test.want, test.found)
+		}
+	}
+}
+
+func TestSnapshotDue(t *testing.T) {
+	entries := snapshotPages * logPageSize
+
+	tests := []struct {
+		name   string
+		count  int
+		turn   int
+		phase  game.Phase
+		base   int
+		length int
+		want   bool
+	}{
+		{"first save", 0, 0, Setup, 0, 1, true},
+		{"same phase", 1, 1, CollectWorkers, 10, 12, false},
+		{"new phase", 1, 1, Actions, 10, 12, true},
+		{"new turn", 1, 2, CollectWorkers, 10, 12, true},
+		{"log pages", 1, 1, CollectWorkers, entries - 1, entries + 1, true},
+	}
+
+	for _, test := range tests {
+		g := &Game{Header: new(game.Header), State: newState()}
+		g.SnapshotCount = test.count
+		g.Turn, g.Phase, g.LogLength = test.turn, test.phase, test.length
+		old := &game.Header{Turn: 1, Phase: CollectWorkers}
+		if got := g.snapshotDue(old, test.base); got != test.want {
+			t.Errorf("%s: snapshotDue() = %v; want %v", test.name, got, test.want)
+		}
+	}
+}
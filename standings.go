@@ -0,0 +1,95 @@
+package atf
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// majority reports who would score the worker majority of a scoring area were the game to end now.
+type majority struct {
+	Area     string `json:"area"`
+	Points   int    `json:"points"`
+	HolderID int    `json:"holderId"`
+	Workers  []int  `json:"workers"`
+	// TiedIDs lists the players tied for the most workers, in which case no one scores the area.
+	TiedIDs []int `json:"tiedIds,omitempty"`
+}
+
+type standing struct {
+	PlayerID  int      `json:"playerId"`
+	Name      string   `json:"name"`
+	Score     int      `json:"score"`
+	Majority  int      `json:"majority"`
+	Projected int      `json:"projected"`
+	Areas     []string `json:"areas,omitempty"`
+}
+
+// majorityIn returns the current majority of scoring area a, as scored by endGameScoring.
+func (g *Game) majorityIn(a *Area) majority {
+	m := majority{Area: a.Name(), Points: a.Score(), HolderID: NoPlayerID, Workers: make([]int, g.NumPlayers)}
+
+	most := 0
+	for _, p := range g.Players() {
+		w := p.WorkersIn(a)
+		m.Workers[p.ID()] = w
+		if w > most {
+			most = w
+		}
+		if p.hasMostWorkersIn(a) {
+			m.HolderID = p.ID()
+		}
+	}
+
+	if m.HolderID == NoPlayerID && most > 0 {
+		for _, p := range g.Players() {
+			if p.WorkersIn(a) == most {
+				m.TiedIDs = append(m.TiedIDs, p.ID())
+			}
+		}
+	}
+	return m
+}
+
+// standings returns the current worker majorities and the scores of the players were the game to end now.
+func (g *Game) standings() ([]majority, []standing) {
+	ms := make([]majority, len(g.scoringIDS()))
+	for i, aid := range g.scoringIDS() {
+		ms[i] = g.majorityIn(g.Areas[aid])
+	}
+
+	ss := make([]standing, len(g.Players()))
+	for i, p := range g.Players() {
+		s := standing{PlayerID: p.ID(), Name: g.NameFor(p), Score: p.Score}
+		for _, m := range ms {
+			if m.HolderID == p.ID() {
+				s.Majority += m.Points
+				s.Areas = append(s.Areas, m.Area)
+			}
+		}
+		s.Projected = s.Score + s.Majority
+		ss[i] = s
+	}
+	return ms, ss
+}
+
+// majorityStandings reports who holds each end-game worker majority and the projected final scores.
+func (client *Client) majorityStandings(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		ms, ss := g.standings()
+		c.JSON(http.StatusOK, gin.H{
+			"turn":       g.Turn,
+			"majorities": ms,
+			"standings":  ss,
+		})
+	}
+}
@@ -14,40 +14,38 @@ import (
 
 func init() {
 	gob.Register(new(startEmpireEntry))
-	gob.Register(new(babylonPrivilegeEntry))
+	// registered by its former name, so entries logged before privileges were registered decode
+	gob.RegisterName("*atf.babylonPrivilegeEntry", new(startEmpirePrivilegeEntry))
 }
 
-func (g *Game) startEmpire(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) startEmpire(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	armies, babylonArmies, empire, err := g.validateStartEmpire(c, cu)
-	if err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
 	cp := g.CurrentPlayer()
-	cp.Army = armies + babylonArmies
-	cp.ArmySupply -= armies + babylonArmies
+	empire := g.empireIn(g.SelectedArea())
+	armies, privilegeArmies := empire.Armies, cp.privilegeBonus(onStartEmpire)
+	cp.Army = armies + privilegeArmies
+	cp.ArmySupply -= armies + privilegeArmies
 	empire.OwnerID = cp.ID()
 	g.MultiAction = startedEmpireMA
+	g.emit(cp, &EmpireStarted{Area: g.SelectedArea().Name(), Armies: armies + privilegeArmies})
 
 	// Log Start Empire
 	e1 := cp.newStartEmpireEntry(g.SelectedArea(), armies)
 	restful.AddNoticef(c, string(e1.HTML()))
 
-	// Log Babylon Privilege
-	if babylonArmies == 2 {
-		e2 := cp.newBabylonPrivilegeEntry()
+	// Log Start Empire Privilege
+	if privilegeArmies > 0 {
+		e2 := cp.newStartEmpirePrivilegeEntry(privilegeArmies)
 		restful.AddNoticef(c, string(e2.HTML()))
 	}
 
-	tmpl, act = "atf/area_dialog", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateStartEmpire(c *gin.Context, cu *user.User) (armies int, priv int, empire *Empire, err error) {
+func (g *Game) validateStartEmpire(cu *user.User, args *actionArgs) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
@@ -57,38 +55,41 @@ func (g *Game) validateStartEmpire(c *gin.Context, cu *user.User) (armies int, p
 		return
 	}
 
-	aid := Sumer
-	if !a.IsSumer() {
-		aid = a.ID
-	}
-
-	for _, empire = range g.CurrentEmpires() {
-		if aid == empire.AreaID {
-			if empire.Owner() == nil {
-				armies = empire.Armies
-				break
-			} else {
-				err = sn.NewVError("The %s empire was already started.", a.Name())
-				return
-			}
+	var armies int
+	if empire := g.empireIn(a); empire != nil {
+		if empire.Owner() != nil {
+			err = sn.NewVError("The %s empire was already started.", a.Name())
+			return
 		}
+		armies = empire.Armies
 	}
 
 	var cp *Player
-	switch cp, err = g.CurrentPlayer(), g.validatePlayerAction(cu); {
+	switch cp, err = g.CurrentPlayer(), g.validateMultiAction(cu, "start-empire"); {
 	case err != nil:
 	case armies == 0:
 		err = sn.NewVError("You can't start an empire in %s.", a.Name())
 	case !a.IsSumer() && !cp.hasSameOrMoreWorkersIn(a):
 		err = sn.NewVError("You don't have enough workers in %s to start an empire.", a.Name())
-	case cp.PerformedAction:
-		err = sn.NewVError("You have already performed an action.")
-	default:
-		priv = cp.receivedBabylonPrivilege()
 	}
 	return
 }
 
+// empireIn returns the current empire started in a, if any.
+func (g *Game) empireIn(a *Area) *Empire {
+	aid := Sumer
+	if !a.IsSumer() {
+		aid = a.ID
+	}
+
+	for _, empire := range g.CurrentEmpires() {
+		if aid == empire.AreaID {
+			return empire
+		}
+	}
+	return nil
+}
+
 type startEmpireEntry struct {
 	*Entry
 	AreaName      string
@@ -104,7 +105,6 @@ func (p *Player) newStartEmpireEntry(area *Area, armies int) *startEmpireEntry {
 		AreaName: area.Name(),
 		Armies:   armies,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -113,46 +113,52 @@ func (e *startEmpireEntry) HTML() template.HTML {
 	return restful.HTML("%s received %d armies for starting empire in %s.", e.Player().Name(), e.Armies, e.AreaName)
 }
 
-type babylonPrivilegeEntry struct {
+type startEmpirePrivilegeEntry struct {
 	*Entry
+	AreaName string
+	Armies   int
 }
 
-func (p *Player) newBabylonPrivilegeEntry() *babylonPrivilegeEntry {
+func (p *Player) newStartEmpirePrivilegeEntry(armies int) *startEmpirePrivilegeEntry {
 	g := p.Game()
-	e := &babylonPrivilegeEntry{
-		Entry: p.newEntry(),
+	e := &startEmpirePrivilegeEntry{
+		Entry:  p.newEntry(),
+		Armies: armies,
+	}
+	for _, aid := range areaIDS() {
+		if priv, ok := g.privilegeIn(aid); ok && priv.Trigger == onStartEmpire {
+			e.AreaName = aid.Name()
+		}
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
 
-func (e *babylonPrivilegeEntry) HTML() template.HTML {
-	return restful.HTML("%s received 2 armies for city in Babylon.", e.Player().Name())
+func (e *startEmpirePrivilegeEntry) HTML() template.HTML {
+	if e.AreaName == "" {
+		return restful.HTML("%s received 2 armies for city in Babylon.", e.Player().Name())
+	}
+	return restful.HTML("%s received %d armies for city in %s.", e.Player().Name(), e.Armies, e.AreaName)
 }
 
-func (g *Game) cancelStartEmpire(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) cancelStartEmpire(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if cp := g.CurrentPlayer(); !g.IsCurrentPlayer(cu) {
-		tmpl, act, err = "atf/flash_notice", game.None, sn.NewVError("Only the current player may perform this action.")
-	} else {
-		restful.AddNoticef(c, "%s canceled start of empire in %s.", g.NameFor(cp), g.SelectedArea().Name())
-		tmpl, act, err = "", game.Undo, nil
-	}
+	cp := g.CurrentPlayer()
+	restful.AddNoticef(c, "%s canceled start of empire in %s.", g.NameFor(cp), g.SelectedArea().Name())
+	tmpl, act = "", game.Undo
 	return
 }
 
-func (g *Game) confirmStartEmpire(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) validateCancelStartEmpire(cu *user.User, args *actionArgs) error {
+	return g.validateMultiAction(cu, "cancel-start-empire")
+}
+
+func (g *Game) confirmStartEmpire(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validateConfirmStartEmpire(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
 	sa := g.SelectedArea()
 	cp := g.CurrentPlayer()
 	success := 5
@@ -162,6 +168,13 @@ func (g *Game) confirmStartEmpire(c *gin.Context, cu *user.User) (tmpl string, a
 
 	for cp.Army > 0 && sa.Armies > 0 {
 		d1, d2 := roll2D6()
+		g.emit(cp, &AreaInvaded{
+			Area:       sa.Name(),
+			DefenderID: sa.ArmyOwnerID,
+			Roll:       d1 + d2,
+			Needed:     success,
+			Success:    d1+d2 >= success,
+		})
 		if d1+d2 >= success {
 			sa.ArmyOwner().ArmySupply += 1
 			sa.Armies -= 1
@@ -182,16 +195,14 @@ func (g *Game) confirmStartEmpire(c *gin.Context, cu *user.User) (tmpl string, a
 	return
 }
 
-func (g *Game) validateConfirmStartEmpire(c *gin.Context, cu *user.User) (err error) {
+func (g *Game) validateConfirmStartEmpire(cu *user.User, args *actionArgs) (err error) {
 	var a *Area
-	switch a, err = g.SelectedArea(), g.validatePlayerAction(cu); {
+	switch a, err = g.SelectedArea(), g.validateMultiAction(cu, "confirm-start-empire"); {
 	case err != nil:
 	case a == nil:
 		err = sn.NewVError("No area selected.")
 	case g.Phase != Actions:
 		err = sn.NewVError("You can't expand empire during the %q phase.", g.PhaseName())
-	case g.MultiAction != equippedArmyMA:
-		err = sn.NewVError("You can't expand empire while performing a %q action.", g.MultiAction)
 	}
 	return
 }
@@ -18,24 +18,20 @@ func init() {
 	gob.Register(new(makeToolEntry))
 }
 
-func (g *Game) tradeResource(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) tradeResource(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var (
-		gave, received Resources
-		usedSippar     bool
-	)
-
-	if gave, received, usedSippar, err = g.validateTrade(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
+	gave, received := args.Resources, args.Received
 	cp := g.CurrentPlayer()
 	cp.PerformedAction = true
+	usedPrivilege := cp.usesTradePrivilege(g.SelectedArea(), received)
+	var privilege string
+	if priv, ok := cp.privilege(onTrade); ok && usedPrivilege {
+		privilege = priv.Name
+	}
 	if cp.CanUseSippar() {
-		cp.UsedSippar = usedSippar
+		cp.UsedSippar = usedPrivilege
 	}
 	for resource, count := range gave {
 		cp.Resources[resource] -= count
@@ -53,13 +49,13 @@ func (g *Game) tradeResource(c *gin.Context, cu *user.User) (tmpl string, act ga
 	g.MultiAction = tradedResourceMA
 
 	// Log
-	e := cp.newTradeEntry(gave, received, usedSippar)
+	e := cp.newTradeEntry(gave, received, privilege)
 	restful.AddNoticef(c, string(e.HTML()))
-	tmpl, act = "atf/trade_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateTrade(c *gin.Context, cu *user.User) (gave Resources, received Resources, usedSippar bool, err error) {
+func (g *Game) validateTrade(cu *user.User, args *actionArgs) (err error) {
 	cp := g.CurrentPlayer()
 	a := g.SelectedArea()
 
@@ -71,27 +67,26 @@ func (g *Game) validateTrade(c *gin.Context, cu *user.User) (gave Resources, rec
 		return
 	}
 
-	gave, received = getTrades(c)
+	_, err = cp.checkTrade(a, args.Resources, args.Received)
+	return
+}
+
+// checkTrade validates that p may give the gave resources for the received resources in a,
+// and reports whether doing so uses the trade privilege.
+func (p *Player) checkTrade(a *Area, gave, received Resources) (usedPrivilege bool, err error) {
+	g := p.Game()
 	total := 0
 	for resource, count := range received {
 		name := g.ResourceName(resource)
 		switch {
 		case count > 0 && a.Trade[resource] == noTrade:
 			err = sn.NewVError("You can't trade for %s in %s.", name, a.Name())
-		case count == 1 && a.Trade[resource] == traded:
-			if cp.CanUseSippar() {
-				usedSippar = true
-			} else {
-				err = sn.NewVError("You have already received %s from %s.", name, a.Name())
-			}
+		case count == 1 && a.Trade[resource] == traded && !p.CanUseSippar():
+			err = sn.NewVError("You have already received %s from %s.", name, a.Name())
 		case count > 2:
 			err = sn.NewVError("You can't trade for %d %s in %s.", count, name, a.Name())
-		case count == 2:
-			if cp.CanUseSippar() {
-				usedSippar = true
-			} else {
-				err = sn.NewVError("You can't trade for %d %s in %s.", count, name, a.Name())
-			}
+		case count == 2 && !p.CanUseSippar():
+			err = sn.NewVError("You can't trade for %d %s in %s.", count, name, a.Name())
 		}
 		total += count
 	}
@@ -99,19 +94,17 @@ func (g *Game) validateTrade(c *gin.Context, cu *user.User) (gave Resources, rec
 	switch {
 	case total < 1:
 		err = sn.NewVError("You must trade for at least one resource.")
-	case cp.availableTradersIn(a) < 1:
+	case p.availableTradersIn(a) < 1:
 		err = sn.NewVError("You do not have an available trader in %s", a.Name())
-	case total > cp.availableTradersIn(a):
-		err = sn.NewVError("You attempted to make %d trades, but you have %d available traders in %s.", total, cp.availableTradersIn(a), a.Name())
-	case cp.CanUseSippar() && total == cp.availableTradersIn(a):
-		usedSippar = true
+	case total > p.availableTradersIn(a):
+		err = sn.NewVError("You attempted to make %d trades, but you have %d available traders in %s.", total, p.availableTradersIn(a), a.Name())
 	}
 
 	gaveTotal := 0
 	for resource, count := range gave {
 		gaveTotal += count
 		name := g.ResourceName(resource)
-		if cp.Resources[resource]+received[resource] < count {
+		if p.Resources[resource]+received[resource] < count {
 			err = sn.NewVError("You do not have enough %s to perform the requested trade.", name)
 		}
 	}
@@ -128,28 +121,47 @@ func (g *Game) validateTrade(c *gin.Context, cu *user.User) (gave Resources, rec
 			}
 		}
 	}
+	usedPrivilege = p.usesTradePrivilege(a, received)
 	return
 
 }
 
+// usesTradePrivilege reports whether receiving the received resources in a uses the trade privilege of p.
+func (p *Player) usesTradePrivilege(a *Area, received Resources) bool {
+	if !p.CanUseSippar() {
+		return false
+	}
+
+	total := 0
+	for resource, count := range received {
+		if count == 2 || (count == 1 && a.Trade[resource] == traded) {
+			return true
+		}
+		total += count
+	}
+	return total == p.availableTradersIn(a)
+}
+
 type tradeEntry struct {
 	*Entry
 	AreaName   string
 	Gave       Resources
 	Received   Resources
 	UsedSippar bool
+	Privilege  string
 }
 
-func (p *Player) newTradeEntry(gave, received Resources, usedSippar bool) *tradeEntry {
+// newTradeEntry logs a trade, naming the privilege used, if any.
+func (p *Player) newTradeEntry(gave, received Resources, privilege string) *tradeEntry {
 	g := p.Game()
 	e := &tradeEntry{
 		Entry:      p.newEntry(),
 		AreaName:   g.SelectedArea().Name(),
 		Gave:       gave,
 		Received:   received,
-		UsedSippar: usedSippar,
+		UsedSippar: privilege != "",
+		Privilege:  privilege,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -169,22 +181,22 @@ func (e *tradeEntry) HTML() template.HTML {
 		}
 	}
 	if e.UsedSippar {
-		return restful.HTML("%s used Sippar privilege and gave %v to %s and received %v.", e.Player().Name(),
-			restful.ToSentence(gave), e.AreaName, restful.ToSentence(received))
+		// entries logged before privileges were named only used the Sippar privilege
+		privilege := e.Privilege
+		if privilege == "" {
+			privilege = "Sippar"
+		}
+		return restful.HTML("%s used the %s privilege and gave %v to %s and received %v.", e.Player().Name(),
+			privilege, restful.ToSentence(gave), e.AreaName, restful.ToSentence(received))
 	}
 	return restful.HTML("%s gave %v to %s and received %v.", e.Player().Name(),
 		restful.ToSentence(gave), e.AreaName, restful.ToSentence(received))
 }
 
-func (g *Game) makeTool(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) makeTool(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validateMakeTool(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
 	cp := g.CurrentPlayer()
 	cp.PerformedAction = true
 	cp.Resources[Metal] -= 1
@@ -199,11 +211,11 @@ func (g *Game) makeTool(c *gin.Context, cu *user.User) (tmpl string, act game.Ac
 	// Log
 	e := cp.newMakeToolEntry()
 	restful.AddNoticef(c, string(e.HTML()))
-	tmpl, act = "atf/make_tool_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateMakeTool(c *gin.Context, cu *user.User) (err error) {
+func (g *Game) validateMakeTool(cu *user.User, args *actionArgs) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
@@ -212,14 +224,12 @@ func (g *Game) validateMakeTool(c *gin.Context, cu *user.User) (err error) {
 		cp *Player
 	)
 
-	switch a, cp, err = g.SelectedArea(), g.CurrentPlayer(), g.validatePlayerAction(cu); {
+	switch a, cp, err = g.SelectedArea(), g.CurrentPlayer(), g.validateMultiAction(cu, "make-tool"); {
 	case err != nil:
 	case a == nil:
 		err = sn.NewVError("No area selected.")
 	case a.ID != ToolMakers:
 		err = sn.NewVError("You can't make a tool in %s.", a.Name())
-	case cp.PerformedAction && g.MultiAction != tradedResourceMA:
-		err = sn.NewVError("You have already performed an action.")
 	case cp.WorkersIn(a) < 1:
 		err = sn.NewVError("You don't have a toolmaker with which to make a tool.")
 	case cp.Resources[Metal] < 1:
@@ -235,7 +245,6 @@ type makeToolEntry struct {
 func (p *Player) newMakeToolEntry() *makeToolEntry {
 	g := p.Game()
 	e := &makeToolEntry{Entry: p.newEntry()}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -0,0 +1,245 @@
+package atf
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultTradeLimit = 10
+
+// tradeBundle is a legal set of trades in an area.  Each received resource is paid for with one given resource.
+type tradeBundle struct {
+	Receive       map[string]int `json:"receive"`
+	Give          map[string]int `json:"give"`
+	Gain          int            `json:"gain"`
+	UsesPrivilege bool           `json:"usesPrivilege"`
+	Shortfall     int            `json:"shortfall,omitempty"`
+	Form          url.Values     `json:"form"`
+
+	received, given Resources
+}
+
+// tradeBundles returns the legal trade bundles p may make in a.
+// Candidate bundles pay for each received resource with a resource it may be traded for,
+// and are kept if they pass the checks applied to a trade-resource action.
+func (p *Player) tradeBundles(a *Area) []*tradeBundle {
+	var offered []Resource
+	for i, status := range a.Trade {
+		if status != noTrade {
+			offered = append(offered, Resource(i))
+		}
+	}
+
+	var (
+		bs       []*tradeBundle
+		traders  = p.availableTradersIn(a)
+		received = make(Resources, 8)
+		given    = make(Resources, 8)
+	)
+
+	var enumerate func(i, total int)
+	enumerate = func(i, total int) {
+		if i == len(offered) {
+			if b := p.newTradeBundle(a, received, given); b != nil {
+				bs = append(bs, b)
+			}
+			return
+		}
+
+		enumerate(i+1, total)
+
+		if total+1 > traders {
+			return
+		}
+
+		// at most two of a resource may be received, each paid for by a resource it may be traded for
+		r := offered[i]
+		var pays []Resource
+		for j, status := range r.trade() {
+			if status == trade {
+				pays = append(pays, Resource(j))
+			}
+		}
+		for x, j := range pays {
+			received[r] += 1
+			given[j] += 1
+			enumerate(i+1, total+1)
+			if total+2 <= traders {
+				for _, j2 := range pays[x:] {
+					received[r] += 1
+					given[j2] += 1
+					enumerate(i+1, total+2)
+					received[r] -= 1
+					given[j2] -= 1
+				}
+			}
+			received[r] -= 1
+			given[j] -= 1
+		}
+	}
+	enumerate(0, 0)
+	return bs
+}
+
+// newTradeBundle returns the bundle for the trades, or nil if p can not make them.
+func (p *Player) newTradeBundle(a *Area, received, given Resources) *tradeBundle {
+	usedPrivilege, err := p.checkTrade(a, given, received)
+	if err != nil {
+		return nil
+	}
+
+	b := &tradeBundle{
+		Receive:       make(map[string]int),
+		Give:          make(map[string]int),
+		Gain:          received.Value() - given.Value(),
+		UsesPrivilege: usedPrivilege,
+		Form:          make(url.Values),
+		received:      append(Resources(nil), received...),
+		given:         append(Resources(nil), given...),
+	}
+	for i := range received {
+		r := Resource(i)
+		if received[i] > 0 {
+			b.Receive[r.LString()] = received[i]
+		}
+		if given[i] > 0 {
+			b.Give[r.LString()] = given[i]
+		}
+	}
+	return b
+}
+
+// rankTradeBundles orders bundles by gain in value or, given a target, by how nearly each meets the target.
+func (p *Player) rankTradeBundles(bs []*tradeBundle, target Resources) {
+	for _, b := range bs {
+		b.Shortfall = 0
+		for i, want := range target {
+			if have := p.Resources[i] + b.received[i] - b.given[i]; have < want {
+				b.Shortfall += want - have
+			}
+		}
+	}
+
+	sort.SliceStable(bs, func(i, j int) bool {
+		if bs[i].Shortfall != bs[j].Shortfall {
+			return bs[i].Shortfall < bs[j].Shortfall
+		}
+		return bs[i].Gain > bs[j].Gain
+	})
+}
+
+// setForm sets the form values that submit the trades of b as a trade-resource action.
+// Form values pair each received resource with a given resource,
+// so a resource received twice has two values.
+func (b *tradeBundle) setForm() {
+	var gives []string
+	for i, cnt := range b.given {
+		for k := 0; k < cnt; k++ {
+			gives = append(gives, Resource(i).LString())
+		}
+	}
+
+	for i, cnt := range b.received {
+		r := Resource(i)
+		for k := 0; k < cnt && len(gives) > 0; k++ {
+			b.Form.Add(r.LString()+"-traded-resource", gives[0])
+			gives = gives[1:]
+		}
+	}
+}
+
+// parseTarget parses targets of the form "tool:2,oil:1".
+func parseTarget(s string) (Resources, error) {
+	target := make(Resources, 8)
+	if s == "" {
+		return target, nil
+	}
+
+	for _, item := range strings.Split(s, ",") {
+		parts := strings.SplitN(item, ":", 2)
+		r := toResource(parts[0])
+		if r == noResource {
+			return nil, errors.New("unknown resource: " + parts[0])
+		}
+		cnt := 1
+		if len(parts) == 2 {
+			var err error
+			if cnt, err = strconv.Atoi(parts[1]); err != nil {
+				return nil, err
+			}
+		}
+		target[r] = cnt
+	}
+	return target, nil
+}
+
+// tradeOptimizer ranks the legal trade bundles of a player in an area.
+// The area is given by the area parameter or is the selected area.
+// Bundles are ranked by gain in resource value or, given a target (e.g., target=tool:2), by how nearly they meet it.
+func (client *Client) tradeOptimizer(prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client.Log.Debugf(msgEnter)
+		defer client.Log.Debugf(msgExit)
+
+		g := gameFrom(c)
+		if g == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+
+		p, err := client.requestedPlayer(c, g)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		a := g.SelectedArea()
+		if name := c.Query("area"); name != "" {
+			a = nil
+			if aid := toAreaID(name); aid >= 0 && int(aid) < len(g.Areas) {
+				a = g.Areas[aid]
+			}
+		}
+		if a == nil || !g.tradeIDS().include(a.ID) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A trade area must be selected."})
+			return
+		}
+
+		target, err := parseTarget(c.Query("target"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultTradeLimit)))
+		if err != nil || limit < 1 {
+			limit = defaultTradeLimit
+		}
+
+		bs := p.tradeBundles(a)
+		p.rankTradeBundles(bs, target)
+		if len(bs) > limit {
+			bs = bs[:limit]
+		}
+		for _, b := range bs {
+			b.setForm()
+		}
+
+		h := gin.H{
+			"playerId": p.ID(),
+			"area":     a.Name(),
+			"traders":  p.availableTradersIn(a),
+			"bundles":  bs,
+		}
+		if err := p.canTradeIn(a); err != nil {
+			h["reason"] = err.Error()
+		}
+		c.JSON(http.StatusOK, h)
+	}
+}
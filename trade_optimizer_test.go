@@ -0,0 +1,88 @@
+package atf
+
+import "testing"
+
+// newTestGame returns a three player game with the board and supply set up.
+func newTestGame() *Game {
+	g := New(nil, 1)
+	g.NumPlayers = 3
+	for i := 0; i < g.NumPlayers; i++ {
+		p := newPlayer()
+		p.SetID(i)
+		p.SetGame(g)
+		g.Playerers = append(g.Playerers, p)
+	}
+	g.createAreas()
+	g.Resources = g.variant().supply()
+	return g
+}
+
+func TestTradeBundles(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		sippar  bool
+		// retraded marks the area's first trade resource as traded
+		retraded bool
+		// wantMax is the most resources received by any bundle
+		wantMax int
+		// wantDouble reports whether some bundle receives two of a resource
+		wantDouble bool
+	}{
+		{name: "no traders", workers: 0, wantMax: 0},
+		{name: "one trader", workers: 1, wantMax: 1},
+		{name: "two traders", workers: 2, wantMax: 2},
+		{name: "sippar extra trader", workers: 1, sippar: true, wantMax: 2, wantDouble: true},
+		{name: "sippar re-trade", workers: 2, sippar: true, retraded: true, wantMax: 2, wantDouble: true},
+		{name: "re-trade without sippar", workers: 2, retraded: true, wantMax: 1},
+	}
+
+	for _, test := range tests {
+		g := newTestGame()
+		p := g.Players()[0]
+		for i := range p.Resources {
+			p.Resources[i] = 3
+		}
+
+		a := g.Areas[Dilmun]
+		p.setWorkersIn(a, test.workers)
+		first := g.boardMap().trade[a.ID][0]
+		if test.retraded {
+			a.Trade[first] = traded
+		}
+		if test.sippar {
+			sippar := g.Areas[Sippar]
+			sippar.City.Built, sippar.City.OwnerID = true, p.ID()
+		}
+
+		max, double, retrades := 0, false, false
+		for _, b := range p.tradeBundles(a) {
+			total := 0
+			for i, cnt := range b.received {
+				total += cnt
+				if cnt == 2 {
+					double = true
+				}
+				if test.retraded && cnt > 0 && Resource(i) == first {
+					retrades = true
+				}
+			}
+			if _, err := p.checkTrade(a, b.given, b.received); err != nil {
+				t.Errorf("%s: bundle %v rejected by checkTrade: %v", test.name, b.Receive, err)
+			}
+			if total > max {
+				max = total
+			}
+		}
+
+		if max != test.wantMax {
+			t.Errorf("%s: most received = %d; want %d", test.name, max, test.wantMax)
+		}
+		if retrades != (test.retraded && test.sippar) {
+			t.Errorf("%s: re-trades %s = %v; want %v", test.name, first.LString(), retrades, test.retraded && test.sippar)
+		}
+		if double != test.wantDouble {
+			t.Errorf("%s: receives two of a resource = %v; want %v", test.name, double, test.wantDouble)
+		}
+	}
+}
@@ -16,15 +16,10 @@ func init() {
 	gob.Register(new(useScribeEntry))
 }
 
-func (g *Game) useScribe(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) useScribe(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	if err = g.validateUseScribe(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
 	cp := g.CurrentPlayer()
 	cp.incWorkersIn(g.Areas[Scribes], -1)
 	cp.incWorkersIn(g.Areas[UsedScribes], 1)
@@ -32,11 +27,11 @@ func (g *Game) useScribe(c *gin.Context, cu *user.User) (tmpl string, act game.A
 	cp.PerformedAction = false
 
 	restful.AddNoticef(c, "Select worker to move.")
-	tmpl, act = "atf/use_scribe_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateUseScribe(c *gin.Context, cu *user.User) (err error) {
+func (g *Game) validateUseScribe(cu *user.User, args *actionArgs) (err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
@@ -45,14 +40,12 @@ func (g *Game) validateUseScribe(c *gin.Context, cu *user.User) (err error) {
 		cp *Player
 	)
 
-	switch cp, a, err = g.CurrentPlayer(), g.SelectedArea(), g.validatePlayerAction(cu); {
+	switch cp, a, err = g.CurrentPlayer(), g.SelectedArea(), g.validateMultiAction(cu, "use-scribe"); {
 	case err != nil:
 	case a == nil:
 		err = sn.NewVError("No area selected.")
 	case a.ID != Scribes:
 		err = sn.NewVError("You must chose Scribes area in order to use scribe.")
-	case cp.PerformedAction && g.MultiAction != placedWorkerMA && !g.PlacedWorkers:
-		err = sn.NewVError("You have already performed an action.")
 	case cp.WorkersIn(a) < 1:
 		err = sn.NewVError("You don't have a scribe to use.")
 	}
@@ -72,7 +65,6 @@ func (p *Player) newUseScribeEntry() *useScribeEntry {
 		From:  g.From,
 		To:    g.To,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
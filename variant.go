@@ -0,0 +1,202 @@
+package atf
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SlothNinja/sn"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	registerPipelineOption(declineOption)
+}
+
+// variantOption is a game creation option.  Options are stored in the header as "name=value".
+type variantOption struct {
+	Name    string
+	Label   string
+	Values  []string
+	Default string
+}
+
+var variantOptions = []variantOption{
+	{Name: "turns", Label: "Game Length", Values: []string{"3", "4", "5"}, Default: "5"},
+	{Name: "decline", Label: "Decline", Values: []string{"on", "off"}, Default: "on"},
+	{Name: "workers", Label: "Starting Workers", Values: []string{"1", "2", "3", "4"}, Default: "2"},
+	{Name: "resources", Label: "Starting Resources", Values: []string{"standard", "rich"}, Default: "standard"},
+	{Name: "supply", Label: "Supply", Values: []string{"small", "standard", "large"}, Default: "standard"},
+	{Name: "empires", Label: "Empires", Values: []string{"standard", "random"}, Default: "standard"},
+	{Name: "privileges", Label: "City Privileges", Values: []string{"standard", "shuffled"}, Default: "standard"},
+	{
+		Name:    "tiebreak",
+		Label:   "Tie-Breakers",
+		Values:  []string{"resources-cities", "cities-resources", "resources", "cities", "none"},
+		Default: "resources-cities",
+	},
+	// map values are the names of registered maps
+	{Name: "map", Label: "Map", Default: defaultMapName},
+}
+
+func variantOptionFor(name string) (variantOption, bool) {
+	for _, opt := range variantOptions {
+		if opt.Name == name {
+			return opt, true
+		}
+	}
+	return variantOption{}, false
+}
+
+const totalWorkers = 23
+
+var startingResourcesMap = map[string]func() Resources{
+	"standard": defaultResources,
+	"rich": func() Resources {
+		rs := defaultResources()
+		rs[Grain] += 1
+		rs[Textile] += 1
+		return rs
+	},
+}
+
+var supplyMap = map[string]func() Resources{
+	"small":    func() Resources { return Resources{0, 7, 7, 0, 7, 3, 3, 5} },
+	"standard": func() Resources { return Resources{0, 9, 9, 0, 9, 4, 4, 7} },
+	"large":    func() Resources { return Resources{0, 11, 11, 0, 11, 5, 5, 9} },
+}
+
+// variant provides the value of each option of a game, defaults included.
+type variant map[string]string
+
+func (g *Game) variant() variant {
+	v := make(variant, len(variantOptions))
+	for _, opt := range variantOptions {
+		v[opt.Name] = opt.Default
+	}
+	for _, s := range g.Options {
+		if kv := strings.SplitN(s, "=", 2); len(kv) == 2 {
+			if _, ok := variantOptionFor(kv[0]); ok {
+				v[kv[0]] = kv[1]
+			}
+		}
+	}
+	return v
+}
+
+func (v variant) turns() int {
+	turns, err := strconv.Atoi(v["turns"])
+	if err != nil {
+		return 5
+	}
+	return turns
+}
+
+func (v variant) decline() bool {
+	return v["decline"] != "off"
+}
+
+// declineOption skips the decline step of the turn when the variant turns decline off.
+func declineOption(g *Game, name string, p phasePipeline) phasePipeline {
+	if name != startTurnPipeline || g.variant().decline() {
+		return p
+	}
+	return p.skip("decline")
+}
+
+func (v variant) workers() int {
+	workers, err := strconv.Atoi(v["workers"])
+	if err != nil {
+		return 2
+	}
+	return workers
+}
+
+func (v variant) startingResources() Resources {
+	if f, ok := startingResourcesMap[v["resources"]]; ok {
+		return f()
+	}
+	return defaultResources()
+}
+
+func (v variant) supply() Resources {
+	if f, ok := supplyMap[v["supply"]]; ok {
+		return f()
+	}
+	return supplyMap["standard"]()
+}
+
+// options returns the options of v differing from the defaults, sorted by name.
+func (v variant) options() []string {
+	var opts []string
+	for _, opt := range variantOptions {
+		if value := v[opt.Name]; value != opt.Default {
+			opts = append(opts, opt.Name+"="+value)
+		}
+	}
+	sort.Strings(opts)
+	return opts
+}
+
+func (v variant) standard() bool {
+	return len(v.options()) == 0
+}
+
+// Key identifies the rating pool of games played with the variant.
+func (v variant) Key() string {
+	if v.standard() {
+		return "standard"
+	}
+	return strings.Join(v.options(), ",")
+}
+
+// String describes the options of v differing from the defaults.
+func (v variant) String() string {
+	var ss []string
+	for _, opt := range variantOptions {
+		if value := v[opt.Name]; value != opt.Default {
+			ss = append(ss, opt.Label+": "+value)
+		}
+	}
+	if len(ss) == 0 {
+		return "Standard"
+	}
+	return strings.Join(ss, ", ")
+}
+
+// Variant describes the variant of the game for display.
+func (g *Game) Variant() string {
+	return g.variant().String()
+}
+
+// lastTurn returns the turn after which the game ends.
+func (g *Game) lastTurn() int {
+	return g.variant().turns()
+}
+
+// variantFromForm sets the options of the game from the options parameters of the new game form.
+func (g *Game) variantFromForm(c *gin.Context) error {
+	v := g.variant()
+	for _, opt := range variantOptions {
+		value := c.PostForm(opt.Name)
+		if value == "" {
+			continue
+		}
+
+		valid := false
+		for _, allowed := range opt.Values {
+			valid = valid || allowed == value
+		}
+		if !valid {
+			return sn.NewVError("%q is not a valid %s.", value, strings.ToLower(opt.Label))
+		}
+		v[opt.Name] = value
+	}
+
+	g.Options = v.options()
+	g.OptString = ""
+	if !v.standard() {
+		g.OptString = v.String()
+	}
+	return nil
+}
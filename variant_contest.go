@@ -0,0 +1,93 @@
+package atf
+
+import (
+	"cloud.google.com/go/datastore"
+	"github.com/SlothNinja/contest"
+	gtype "github.com/SlothNinja/type"
+)
+
+const (
+	contestKind        = "Contest"
+	variantContestKind = "VariantContest"
+)
+
+// variantContest is a contest of a game played with a non-standard variant.
+// Variant contests are stored under their own kind, apart from the contests rated for the game type,
+// so the results of each variant form a separate rating pool identified by Variant.
+// Like other contests, they record the ratings of opponents for the game type.
+type variantContest struct {
+	contest.Contest
+	Variant string
+}
+
+func (vc *variantContest) Load(ps []datastore.Property) error {
+	rest := make([]datastore.Property, 0, len(ps))
+	for _, p := range ps {
+		if p.Name == "Variant" {
+			vc.Variant, _ = p.Value.(string)
+			continue
+		}
+		rest = append(rest, p)
+	}
+	return vc.Contest.Load(rest)
+}
+
+func (vc *variantContest) Save() ([]datastore.Property, error) {
+	ps, err := vc.Contest.Save()
+	if err != nil {
+		return nil, err
+	}
+	return append(ps, datastore.Property{Name: "Variant", Value: vc.Variant}), nil
+}
+
+func (vc *variantContest) LoadKey(k *datastore.Key) error {
+	vc.Key = k
+	return nil
+}
+
+// contestEntities returns the keys and entities storing cs for a game played with v.
+func (v variant) contestEntities(cs []*contest.Contest) ([]*datastore.Key, []interface{}) {
+	ks := make([]*datastore.Key, len(cs))
+	es := make([]interface{}, len(cs))
+	for i, ct := range cs {
+		if v.standard() {
+			ks[i], es[i] = ct.Key, ct
+			continue
+		}
+
+		vc := &variantContest{Contest: *ct, Variant: v.Key()}
+		vc.Key = datastore.IncompleteKey(variantContestKind, ct.Key.Parent)
+		ks[i], es[i] = vc.Key, vc
+	}
+	return ks, es
+}
+
+// newContests returns n empty entities of the kind storing the contests of games played with v,
+// along with the contests they load.
+func (v variant) newContests(n int) ([]interface{}, []*contest.Contest) {
+	es := make([]interface{}, n)
+	cs := make([]*contest.Contest, n)
+	for i := range es {
+		if v.standard() {
+			ct := new(contest.Contest)
+			es[i], cs[i] = ct, ct
+			continue
+		}
+
+		vc := new(variantContest)
+		es[i], cs[i] = vc, &vc.Contest
+	}
+	return es, cs
+}
+
+// contestQuery returns a query for the stored contests of the game with the provided id, played with v.
+func (v variant) contestQuery(id int64) *datastore.Query {
+	if v.standard() {
+		return datastore.NewQuery(contestKind).
+			Filter("GameID=", id).
+			Filter("Type=", int(gtype.ATF))
+	}
+	return datastore.NewQuery(variantContestKind).
+		Filter("GameID=", id).
+		Filter("Variant=", v.Key())
+}
@@ -0,0 +1,62 @@
+package atf
+
+import (
+	"testing"
+
+	"cloud.google.com/go/datastore"
+	"github.com/SlothNinja/contest"
+	gtype "github.com/SlothNinja/type"
+)
+
+func TestContestEntities(t *testing.T) {
+	ukey := datastore.IDKey("User", 7, nil)
+	ct := contest.New(0, ukey, 1, gtype.ATF, 1500, 350, 1)
+
+	tests := []struct {
+		name     string
+		options  []string
+		wantKind string
+		wantKey  string
+	}{
+		{"standard", nil, contestKind, "standard"},
+		{"variant", []string{"turns=4", "decline=off"}, variantContestKind, "decline=off,turns=4"},
+	}
+
+	for _, test := range tests {
+		g := newTestGame()
+		g.Options = test.options
+		v := g.variant()
+
+		if got := v.Key(); got != test.wantKey {
+			t.Errorf("%s: Key() = %q, want %q", test.name, got, test.wantKey)
+		}
+
+		ks, es := v.contestEntities([]*contest.Contest{ct})
+		if len(ks) != 1 || len(es) != 1 {
+			t.Fatalf("%s: got %d keys and %d entities, want 1", test.name, len(ks), len(es))
+		}
+		if ks[0].Kind != test.wantKind || !ks[0].Parent.Equal(ukey) {
+			t.Errorf("%s: key = %v, want kind %s with parent %v", test.name, ks[0], test.wantKind, ukey)
+		}
+
+		ps, err := es[0].(datastore.PropertyLoadSaver).Save()
+		if err != nil {
+			t.Fatalf("%s: Save() error %v", test.name, err)
+		}
+
+		loaded, cs := v.newContests(1)
+		err = loaded[0].(datastore.PropertyLoadSaver).Load(ps)
+		if err != nil {
+			t.Fatalf("%s: Load() error %v", test.name, err)
+		}
+		if cs[0].GameID != ct.GameID || cs[0].R != ct.R || cs[0].Outcome != ct.Outcome {
+			t.Errorf("%s: loaded contest = %+v, want %+v", test.name, cs[0], ct)
+		}
+		if vc, ok := loaded[0].(*variantContest); ok && vc.Variant != test.wantKey {
+			t.Errorf("%s: loaded variant = %q, want %q", test.name, vc.Variant, test.wantKey)
+		}
+		if _, ok := loaded[0].(*variantContest); ok != (test.wantKind == variantContestKind) {
+			t.Errorf("%s: loaded %T, want kind %s", test.name, loaded[0], test.wantKind)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package atf
+
+import "testing"
+
+func TestDeclineOption(t *testing.T) {
+	tests := []struct {
+		options []string
+		want    bool
+	}{
+		{nil, true},
+		{[]string{"decline=on"}, true},
+		{[]string{"decline=off"}, false},
+	}
+
+	for _, test := range tests {
+		g := newTestGame()
+		g.Options = test.options
+
+		if got := g.pipeline(startTurnPipeline).index("decline") != -1; got != test.want {
+			t.Errorf("options %v: start turn declines = %v, want %v", test.options, got, test.want)
+		}
+		if got := g.pipeline(endOfTurnPipeline); len(got) != len(phasePipelines[endOfTurnPipeline]) {
+			t.Errorf("options %v: end of turn steps = %v, want %v", test.options, stepNames(got), stepNames(phasePipelines[endOfTurnPipeline]))
+		}
+	}
+}
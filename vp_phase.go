@@ -32,18 +32,19 @@ func (g *Game) scoreEmpires(c *gin.Context) {
 	g.beginningOfPhaseReset()
 	g.Phase = ScoreEmpire
 	g.Round = 1
-	sem := make(scoreEmpireMap, len(empireIDS()))
+	sem := make(scoreEmpireMap, len(g.empireIDS()))
 	scores := make([]int, g.NumPlayers)
-	for _, aid := range empireIDS() {
+	for _, aid := range g.empireIDS() {
 		a := g.Areas[aid]
 		if owner := a.ArmyOwner(); owner != nil {
 			score := 2
-			if a.IsSumer() && owner.hasCityIn(Nippur) {
-				score = 3
+			owner.addScore(empireScore, aid, 2)
+			if bonus := owner.privilegeBonus(onScoring); bonus > 0 && a.IsSumer() {
+				score += bonus
+				owner.addScore(privilegeScore, aid, bonus)
 			}
 			sem[aid] = &scoreEmpireRecord{owner.ID(), score}
 			scores[owner.ID()] += score
-			owner.Score += score
 		} else {
 			sem[aid] = &scoreEmpireRecord{NoPlayerID, 0}
 		}
@@ -58,6 +59,7 @@ func (g *Game) scoreEmpires(c *gin.Context) {
 
 	}
 	g.newScoreEmpiresEntry(sem, scores, empires)
+	g.emit(nil, &EmpiresScored{Scores: scores})
 }
 
 type scoreEmpiresEntry struct {
@@ -81,7 +83,7 @@ func (e *scoreEmpiresEntry) HTML() template.HTML {
 	g := e.Game()
 	rowCount := 0
 	rows := restful.HTML("")
-	for _, aid := range empireIDS() {
+	for _, aid := range g.empireIDS() {
 		ser := e.SEM[aid]
 		if ser.Score > 0 {
 			switch ser.PlayerID {
@@ -140,17 +142,11 @@ func (g *Game) expandCityPhase(c *gin.Context, cu *user.User) (completed bool) {
 	return
 }
 
-func (g *Game) expandCity(c *gin.Context, cu *user.User) (tmpl string, act game.ActionType, err error) {
+func (g *Game) expandCity(c *gin.Context, cu *user.User, args *actionArgs) (tmpl string, act game.ActionType, err error) {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	var rs Resources
-
-	if rs, err = g.validateExpandCity(c, cu); err != nil {
-		tmpl, act = "atf/flash_notice", game.None
-		return
-	}
-
+	rs := args.Resources
 	cp := g.CurrentPlayer()
 	spent := 0
 	for i, cnt := range rs {
@@ -177,43 +173,43 @@ func (g *Game) expandCity(c *gin.Context, cu *user.User) (tmpl string, act game.
 	case 6:
 		points = 20
 	}
-	cp.Score += points
+	cp.addScore(cityExpansionScore, area.ID, points)
+	g.emit(cp, &CityExpanded{Area: area.Name(), Points: points})
 
 	// Log Start Empire
 	e := cp.newCityExpansionEntry(area, rs, points)
 	restful.AddNoticef(c, string(e.HTML()))
-	tmpl, act = "atf/expand_city_update", game.Cache
+	act = game.Cache
 	return
 }
 
-func (g *Game) validateExpandCity(c *gin.Context, cu *user.User) (Resources, error) {
+func (g *Game) validateExpandCity(cu *user.User, args *actionArgs) error {
 	log.Debugf(msgEnter)
 	defer log.Debugf(msgExit)
 
-	rs, err := getResourcesFrom(c)
-	if err != nil {
-		return rs, err
+	if err := g.validateMultiAction(cu, "expand-city"); err != nil {
+		return err
 	}
 
 	cp := g.CurrentPlayer()
-	for i, cnt := range rs {
+	for i, cnt := range args.Resources {
 		r := Resource(i)
 		if cnt > cp.Resources[r] {
-			return nil, sn.NewVError("You do not have %d %s.", cnt, r)
+			return sn.NewVError("You do not have %d %s.", cnt, r)
 		}
 		switch r {
 		case Wood:
 			if cnt != 2 {
-				return nil, sn.NewVError("Received %d wood. Must use 2 wood.", cnt)
+				return sn.NewVError("Received %d wood. Must use 2 wood.", cnt)
 			}
 		case Tool, Gold, Oil, Lapis:
 			if cnt != 0 && cnt != 1 {
-				return nil, sn.NewVError("Received %d %s. Must spend only 0 or 1 %s",
+				return sn.NewVError("Received %d %s. Must spend only 0 or 1 %s",
 					cnt, g.ResourceName(i), g.ResourceName(i))
 			}
 		default:
 			if cnt != 0 {
-				return nil, sn.NewVError("Received %d %s. Can't spend a %s to expand city.",
+				return sn.NewVError("Received %d %s. Can't spend a %s to expand city.",
 					cnt, g.ResourceName(i), g.ResourceName(i))
 			}
 
@@ -223,23 +219,23 @@ func (g *Game) validateExpandCity(c *gin.Context, cu *user.User) (Resources, err
 	a := g.SelectedArea()
 	switch {
 	case !g.IsCurrentPlayer(cu):
-		return nil, sn.NewVError("Only the current player can perform an action.")
+		return sn.NewVError("Only the current player can perform an action.")
 	case g.Phase != ExpandCity:
-		return nil, sn.NewVError("You can not expand a city in the %q phase.", g.PhaseName())
+		return sn.NewVError("You can not expand a city in the %q phase.", g.PhaseName())
 	case !a.IsSumer():
-		return nil, sn.NewVError("You can not expand a city in %s", a.Name())
+		return sn.NewVError("You can not expand a city in %s", a.Name())
 	case !a.City.Built:
-		return nil, sn.NewVError("%s does not have a city to expand.", a.Name())
+		return sn.NewVError("%s does not have a city to expand.", a.Name())
 	case a.City.Expanded:
-		return nil, sn.NewVError("The city in %s is already expanded.", a.Name())
+		return sn.NewVError("The city in %s is already expanded.", a.Name())
 	case !a.City.Owner().Equal(cp):
-		return nil, sn.NewVError("You do not own the city in %s.", a.Name())
+		return sn.NewVError("You do not own the city in %s.", a.Name())
 	case cp.Expansion < 1:
-		return nil, sn.NewVError("You do not have an expansion with which to expand the city.")
+		return sn.NewVError("You do not have an expansion with which to expand the city.")
 	case cp.VPPassed:
-		return nil, sn.NewVError("You have already passed.")
+		return sn.NewVError("You have already passed.")
 	default:
-		return rs, nil
+		return nil
 	}
 }
 
@@ -258,7 +254,6 @@ func (p *Player) newCityExpansionEntry(a *Area, r Resources, s int) *cityExpansi
 		Resources: r,
 		Scored:    s,
 	}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }
@@ -282,7 +277,6 @@ type noCityExpansionEntry struct {
 func (p *Player) newNoCityExpansionEntry() *noCityExpansionEntry {
 	g := p.Game()
 	e := &noCityExpansionEntry{Entry: p.newEntry()}
-	p.Log = append(p.Log, e)
 	g.Log = append(g.Log, e)
 	return e
 }